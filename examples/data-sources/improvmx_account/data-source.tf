@@ -0,0 +1,14 @@
+data "improvmx_account" "current" {}
+
+resource "improvmx_smtp_credential" "example" {
+  domain   = "piedpiper.com"
+  username = "richard"
+  password = var.smtp_password
+
+  lifecycle {
+    precondition {
+      condition     = data.improvmx_account.current.premium && data.improvmx_account.current.otp_enabled
+      error_message = "SMTP credentials require a premium account with two-factor authentication enabled."
+    }
+  }
+}
@@ -0,0 +1,8 @@
+data "improvmx_aliases" "billing" {
+  domain        = "piedpiper.com"
+  alias_pattern = "^billing$"
+}
+
+output "billing_forward" {
+  value = one(data.improvmx_aliases.billing.aliases[*].forward)
+}
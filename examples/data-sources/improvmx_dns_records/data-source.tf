@@ -0,0 +1,14 @@
+data "improvmx_dns_records" "example" {
+  domain = "piedpiper.com"
+}
+
+resource "cloudflare_record" "improvmx" {
+  for_each = { for r in data.improvmx_dns_records.example.records : r.key => r }
+
+  zone_id  = var.zone_id
+  type     = each.value.type
+  name     = each.value.name
+  value    = each.value.value
+  priority = each.value.type == "MX" ? each.value.priority : null
+  ttl      = each.value.ttl
+}
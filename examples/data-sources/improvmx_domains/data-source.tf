@@ -0,0 +1,7 @@
+data "improvmx_domains" "active" {
+  is_active = true
+}
+
+output "active_domains" {
+  value = data.improvmx_domains.active.domains[*].domain
+}
@@ -0,0 +1,12 @@
+data "improvmx_logs" "hello" {
+  domain = "piedpiper.com"
+  alias  = "hello"
+  since  = timeadd(plantimestamp(), "-24h")
+}
+
+check "hello_delivered" {
+  assert {
+    condition     = alltrue([for l in data.improvmx_logs.hello.logs : l.status != "hard-bounce"])
+    error_message = "Emails sent to hello@piedpiper.com bounced in the last 24 hours."
+  }
+}
@@ -0,0 +1,12 @@
+data "improvmx_account" "current" {}
+
+data "improvmx_smtp_credentials" "example" {
+  domain = "piedpiper.com"
+}
+
+check "smtp_quota" {
+  assert {
+    condition     = data.improvmx_smtp_credentials.example.total_usage < 0.8 * data.improvmx_account.current.limits[0].daily_send
+    error_message = "SMTP credentials of piedpiper.com have used more than 80% of today's sending quota."
+  }
+}
@@ -0,0 +1,17 @@
+data "improvmx_whitelabels" "available" {}
+
+variable "whitelabel" {
+  type = string
+}
+
+resource "improvmx_domain" "example" {
+  domain     = "piedpiper.com"
+  whitelabel = var.whitelabel
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.improvmx_whitelabels.available.names, var.whitelabel)
+      error_message = "Whitelabel ${var.whitelabel} is not available to this account."
+    }
+  }
+}
@@ -0,0 +1,22 @@
+ephemeral "improvmx_smtp_password" "mailer" {
+  length = 40
+}
+
+resource "aws_secretsmanager_secret" "mailer" {
+  name = "improvmx/piedpiper.com/mailer"
+}
+
+# The same ephemeral password is sent to ImprovMX and to the secret store, and
+# is never written to the plan or state. Bump both versions together to rotate.
+resource "improvmx_smtp_credential" "mailer" {
+  domain           = "piedpiper.com"
+  username         = "mailer"
+  password_wo      = ephemeral.improvmx_smtp_password.mailer.result
+  password_version = 1
+}
+
+resource "aws_secretsmanager_secret_version" "mailer" {
+  secret_id                = aws_secretsmanager_secret.mailer.id
+  secret_string_wo         = ephemeral.improvmx_smtp_password.mailer.result
+  secret_string_wo_version = 1
+}
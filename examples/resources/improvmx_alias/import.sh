@@ -0,0 +1,5 @@
+# Aliases can be imported using the domain and alias name, separated by a slash
+terraform import improvmx_alias.example piedpiper.com/billing
+
+# The catch-all alias is imported using `*` as its name
+terraform import improvmx_alias.catch_all 'piedpiper.com/*'
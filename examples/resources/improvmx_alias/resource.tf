@@ -0,0 +1,12 @@
+resource "improvmx_alias" "example" {
+  domain  = "piedpiper.com"
+  alias   = "billing"
+  forward = "accounts@piedpiper.com"
+}
+
+# Forward to several destinations
+resource "improvmx_alias" "team" {
+  domain   = "piedpiper.com"
+  alias    = "team"
+  forwards = ["richard@piedpiper.com", "jared@piedpiper.com"]
+}
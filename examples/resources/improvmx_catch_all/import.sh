@@ -0,0 +1,2 @@
+# Catch-all can be imported using the domain name
+terraform import improvmx_catch_all.example piedpiper.com
@@ -0,0 +1,14 @@
+resource "improvmx_domain" "example" {
+  domain = "piedpiper.com"
+}
+
+resource "improvmx_catch_all" "example" {
+  domain  = improvmx_domain.example.domain
+  forward = "richard@piedpiper.com"
+}
+
+resource "improvmx_alias" "billing" {
+  domain  = improvmx_domain.example.domain
+  alias   = "billing"
+  forward = "jared@piedpiper.com"
+}
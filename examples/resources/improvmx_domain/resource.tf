@@ -6,3 +6,15 @@ resource "improvmx_domain" "example" {
     forward = "me@example.com"
   }
 }
+
+# Leave aliases added in the ImprovMX dashboard alone
+resource "improvmx_domain" "hooli" {
+  domain                   = "hooli.com"
+  alias_management         = "additive"
+  report_unmanaged_aliases = true
+
+  alias {
+    alias   = "gavin"
+    forward = "gavin@example.com"
+  }
+}
@@ -0,0 +1,2 @@
+# The aliases of a domain can be imported using the domain name
+terraform import improvmx_domain_aliases.example piedpiper.com
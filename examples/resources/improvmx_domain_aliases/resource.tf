@@ -0,0 +1,18 @@
+resource "improvmx_domain_aliases" "example" {
+  domain = "piedpiper.com"
+
+  alias {
+    alias   = "hello"
+    forward = "richard@piedpiper.com"
+  }
+
+  alias {
+    alias    = "team"
+    forwards = ["richard@piedpiper.com", "gilfoyle@piedpiper.com"]
+  }
+
+  alias {
+    alias   = "*"
+    forward = "support@piedpiper.com"
+  }
+}
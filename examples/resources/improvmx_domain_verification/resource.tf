@@ -0,0 +1,25 @@
+resource "improvmx_domain" "example" {
+  domain = "piedpiper.com"
+}
+
+# DNS records created with another provider, e.g. Cloudflare
+resource "cloudflare_record" "mx" {
+  for_each = toset(["mx1.improvmx.com", "mx2.improvmx.com"])
+
+  zone_id  = var.zone_id
+  name     = "@"
+  type     = "MX"
+  value    = each.value
+  priority = each.value == "mx1.improvmx.com" ? 10 : 20
+}
+
+resource "improvmx_domain_verification" "example" {
+  domain  = improvmx_domain.example.domain
+  records = ["mx"]
+
+  timeouts {
+    create = "15m"
+  }
+
+  depends_on = [cloudflare_record.mx]
+}
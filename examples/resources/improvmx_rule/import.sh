@@ -0,0 +1,2 @@
+# Rules can be imported using the domain and rule ID, separated by a slash
+terraform import improvmx_rule.invoices piedpiper.com/65f1b2c3d4
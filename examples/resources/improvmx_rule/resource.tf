@@ -0,0 +1,31 @@
+resource "improvmx_rule" "invoices" {
+  domain   = "piedpiper.com"
+  priority = 10
+
+  condition {
+    field    = "subject"
+    operator = "contains"
+    value    = "invoice"
+  }
+
+  action {
+    type        = "forward"
+    destination = "billing@piedpiper.com"
+  }
+}
+
+resource "improvmx_rule" "newsletters" {
+  domain   = "piedpiper.com"
+  priority = 20
+
+  condition {
+    field    = "header"
+    header   = "List-Unsubscribe"
+    operator = "matches"
+    value    = ".+"
+  }
+
+  action {
+    type = "drop"
+  }
+}
@@ -0,0 +1,3 @@
+# SMTP credentials can be imported using the domain and username, separated by a slash.
+# The API never returns passwords, so the next apply sets the configured password.
+terraform import improvmx_smtp_credential.example piedpiper.com/mailer
@@ -0,0 +1,10 @@
+# The write-only password is never stored in the plan or state. Bump
+# password_version to rotate it. To generate the password and store it in a
+# secret store, see the example of the improvmx_smtp_password ephemeral
+# resource in docs/ephemeral-resources/smtp_password.md.
+resource "improvmx_smtp_credential" "mailer" {
+  domain           = "piedpiper.com"
+  username         = "mailer"
+  password_wo      = var.mailer_password
+  password_version = 1
+}
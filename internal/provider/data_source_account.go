@@ -0,0 +1,187 @@
+package improvmx
+
+import (
+	"context"
+	"time"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAccount() *schema.Resource {
+	return &schema.Resource{
+		Description: "Returns the plan, limits and status of the ImprovMX account that owns the API key. Billing details are only read when `include_billing` is set.",
+
+		ReadContext: dataSourceAccountRead,
+
+		Schema: map[string]*schema.Schema{
+			"include_billing": {
+				Description: "Set to `true` to read the account's billing details into `billing`.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"premium": {
+				Description: "True if the account is on a paid plan.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"locked": {
+				Description: "True if the account has been locked.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"lock_reason": {
+				Description: "Reason the account was locked.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"otp_enabled": {
+				Description: "True if two-factor authentication is enabled for the account.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+			"created": {
+				Description: "Timestamp when the account was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"renew_date": {
+				Description: "Timestamp when the plan renews.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"renews_at": {
+				Description: "Time the plan renews, in RFC 3339 format. Empty if the plan does not renew.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"plan": {
+				Description: "Account plan.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name":          {Type: schema.TypeString, Computed: true, Description: "Name of the plan."},
+						"display":       {Type: schema.TypeString, Computed: true, Description: "Display name of the plan."},
+						"kind":          {Type: schema.TypeString, Computed: true, Description: "Kind of plan, e.g. `free` or `premium`."},
+						"price":         {Type: schema.TypeInt, Computed: true, Description: "Price of the plan."},
+						"yearly":        {Type: schema.TypeBool, Computed: true, Description: "True if the plan is billed yearly."},
+						"aliases_limit": {Type: schema.TypeInt, Computed: true, Description: "Maximum number of aliases per domain."},
+						"domains_limit": {Type: schema.TypeInt, Computed: true, Description: "Maximum number of domains."},
+						"daily_quota":   {Type: schema.TypeInt, Computed: true, Description: "Maximum number of emails forwarded per day."},
+					},
+				},
+			},
+			"limits": {
+				Description: "Account limits.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"aliases":      {Type: schema.TypeInt, Computed: true, Description: "Maximum number of aliases per domain."},
+						"api":          {Type: schema.TypeInt, Computed: true, Description: "Maximum number of API requests."},
+						"credentials":  {Type: schema.TypeInt, Computed: true, Description: "Maximum number of SMTP credentials."},
+						"daily_quota":  {Type: schema.TypeInt, Computed: true, Description: "Maximum number of emails forwarded per day."},
+						"daily_send":   {Type: schema.TypeInt, Computed: true, Description: "Maximum number of emails sent through SMTP per day."},
+						"destinations": {Type: schema.TypeInt, Computed: true, Description: "Maximum number of destinations per alias."},
+						"domains":      {Type: schema.TypeInt, Computed: true, Description: "Maximum number of domains."},
+						"ratelimit":    {Type: schema.TypeInt, Computed: true, Description: "Maximum number of emails forwarded per second."},
+						"redirections": {Type: schema.TypeInt, Computed: true, Description: "Maximum number of redirections."},
+						"subdomains":   {Type: schema.TypeInt, Computed: true, Description: "Maximum number of subdomains."},
+					},
+				},
+			},
+			"billing": {
+				Description: "Billing details of the account. Only set when `include_billing` is `true`.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Sensitive:   true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"email":           {Type: schema.TypeString, Computed: true, Description: "Email address of the account."},
+						"billing_email":   {Type: schema.TypeString, Computed: true, Description: "Email address invoices are sent to."},
+						"company_name":    {Type: schema.TypeString, Computed: true, Description: "Company name."},
+						"company_details": {Type: schema.TypeString, Computed: true, Description: "Company details shown on invoices."},
+						"country":         {Type: schema.TypeString, Computed: true, Description: "Country code."},
+						"card_brand":      {Type: schema.TypeString, Computed: true, Description: "Brand of the card on file."},
+						"last4":           {Type: schema.TypeString, Computed: true, Description: "Last four digits of the card on file."},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+
+	account, err := c.Account().Get(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// a provider is configured with a single account
+	d.SetId("account")
+	accountResourceData(account, d)
+	return nil
+}
+
+func accountResourceData(account *improvmx.Account, d *schema.ResourceData) {
+	d.Set("premium", account.Premium)
+	d.Set("locked", account.Locked)
+	d.Set("lock_reason", account.LockReason)
+	d.Set("otp_enabled", account.IsOtpEnabled)
+	d.Set("created", account.Created)
+	d.Set("renew_date", account.RenewDate)
+
+	renewsAt := ""
+	if account.RenewDate > 0 {
+		renewsAt = time.Unix(account.RenewDate, 0).UTC().Format(time.RFC3339)
+	}
+	d.Set("renews_at", renewsAt)
+
+	if p := account.Plan; p != nil {
+		d.Set("plan", []interface{}{map[string]interface{}{
+			"name":          p.Name,
+			"display":       p.Display,
+			"kind":          p.Kind,
+			"price":         p.Price,
+			"yearly":        p.Yearly,
+			"aliases_limit": p.AliasesLimit,
+			"domains_limit": p.DomainsLimit,
+			"daily_quota":   p.DailyQuota,
+		}})
+	}
+
+	if l := account.Limits; l != nil {
+		d.Set("limits", []interface{}{map[string]interface{}{
+			"aliases":      l.Aliases,
+			"api":          l.API,
+			"credentials":  l.Credentials,
+			"daily_quota":  l.DailyQuota,
+			"daily_send":   l.DailySend,
+			"destinations": l.Destinations,
+			"domains":      l.Domains,
+			"ratelimit":    l.Ratelimit,
+			"redirections": l.Redirections,
+			"subdomains":   l.Subdomains,
+		}})
+	}
+
+	// keep billing details out of state unless asked for
+	if !d.Get("include_billing").(bool) {
+		d.Set("billing", nil)
+		return
+	}
+	d.Set("billing", []interface{}{map[string]interface{}{
+		"email":           account.Email,
+		"billing_email":   account.BillingEmail,
+		"company_name":    account.CompanyName,
+		"company_details": account.CompanyDetails,
+		"country":         account.Country,
+		"card_brand":      account.CardBrand,
+		"last4":           account.Last4,
+	}})
+}
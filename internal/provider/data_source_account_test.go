@@ -0,0 +1,58 @@
+package improvmx
+
+import (
+	"testing"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDataSourceAccount(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "improvmx_account" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.improvmx_account.test", "id", "account"),
+					resource.TestCheckResourceAttr("data.improvmx_account.test", "plan.#", "1"),
+					resource.TestCheckResourceAttr("data.improvmx_account.test", "limits.#", "1"),
+					resource.TestCheckResourceAttr("data.improvmx_account.test", "billing.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccountResourceData_Billing(t *testing.T) {
+	account := &improvmx.Account{
+		Email:     "richard@piedpiper.com",
+		Premium:   true,
+		RenewDate: 1700000000,
+		Plan:      &improvmx.AccountPlan{Name: "business"},
+		Limits:    &improvmx.AccountLimit{Destinations: 5},
+	}
+
+	for _, includeBilling := range []bool{false, true} {
+		d := schema.TestResourceDataRaw(t, dataSourceAccount().Schema, map[string]interface{}{
+			"include_billing": includeBilling,
+		})
+		accountResourceData(account, d)
+
+		if got := d.Get("renews_at").(string); got != "2023-11-14T22:13:20Z" {
+			t.Errorf("unexpected renews_at: %s", got)
+		}
+		if got := d.Get("limits.0.destinations").(int); got != 5 {
+			t.Errorf("unexpected destinations limit: %d", got)
+		}
+		email := d.Get("billing.0.email").(string)
+		if includeBilling && email != account.Email {
+			t.Errorf("expected billing email to be set, got %q", email)
+		}
+		if !includeBilling && email != "" {
+			t.Errorf("expected billing to be empty, got %q", email)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package improvmx
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"sort"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceAliases() *schema.Resource {
+	return &schema.Resource{
+		Description: "Returns the aliases of a domain, optionally filtered by name or destination. Reading aliases with this data source does not take ownership of the domain.",
+
+		ReadContext: dataSourceAliasesRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"alias_pattern": {
+				Description:  "Only return aliases whose name matches this regular expression, e.g. `^billing$`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"forward": {
+				Description: "Only return aliases that forward to this email address. The domain of the address is compared case-insensitively.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"aliases": {
+				Description: "Aliases matching the filters, sorted by name.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"alias":   {Type: schema.TypeString, Computed: true, Description: "Alias to be redirected."},
+						"forward": {Type: schema.TypeString, Computed: true, Description: "Destination email address(es)."},
+						"id":      {Type: schema.TypeInt, Computed: true, Description: "Unique ID for alias."},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAliasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	aliases, err := c.Aliases(domain).List(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var pattern *regexp.Regexp
+	if v := d.Get("alias_pattern").(string); v != "" {
+		if pattern, err = regexp.Compile(v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	matched := filterAliases(*aliases, pattern, d.Get("forward").(string))
+
+	aliasList := make([]interface{}, len(matched))
+	for i, a := range matched {
+		aliasList[i] = map[string]interface{}{
+			"alias":   a.Alias,
+			"forward": a.Forward,
+			"id":      a.ID,
+		}
+	}
+
+	d.SetId(domain)
+	d.Set("aliases", aliasList)
+	return nil
+}
+
+// filterAliases returns the aliases whose name matches pattern and that
+// forward to forward, sorted by name. Empty filters match every alias.
+func filterAliases(aliases []improvmx.Alias, pattern *regexp.Regexp, forward string) []improvmx.Alias {
+	matched := []improvmx.Alias{}
+	for _, a := range aliases {
+		if pattern != nil && !pattern.MatchString(a.Alias) {
+			continue
+		}
+		if forward != "" && !forwardsTo(a, forward) {
+			continue
+		}
+		matched = append(matched, a)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Alias < matched[j].Alias })
+	return matched
+}
+
+// forwardsTo reports whether one of the alias's destinations is email,
+// comparing destinations as Destinations normalizes them.
+func forwardsTo(a improvmx.Alias, email string) bool {
+	return slices.Contains(a.Destinations(), improvmx.NormalizeDestination(email))
+}
@@ -0,0 +1,90 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDataSourceAliases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "improvmx_domain" "test" {
+					domain = "%[1]s"
+
+					alias {
+						alias = "billing"
+						forward = "finance@piedpiper.com"
+					}
+
+					alias {
+						alias = "hello"
+						forward = "hello@piedpiper.com"
+					}
+				}
+
+				data "improvmx_aliases" "test" {
+					domain        = "%[1]s"
+					alias_pattern = "^bill"
+					depends_on    = [improvmx_domain.test]
+				}
+				`, testDomain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.improvmx_aliases.test", "aliases.#", "1"),
+					resource.TestCheckResourceAttr("data.improvmx_aliases.test", "aliases.0.forward", "finance@piedpiper.com"),
+				),
+			},
+		},
+	})
+}
+
+func TestFilterAliases(t *testing.T) {
+	aliases := []improvmx.Alias{
+		{Alias: "hello", Forward: "richard@piedpiper.com"},
+		{Alias: "billing", Forward: "jared@piedpiper.com, Richard@piedpiper.com"},
+		{Alias: "billing-eu", Forward: "monica@piedpiper.com"},
+	}
+
+	tests := []struct {
+		pattern *regexp.Regexp
+		forward string
+		want    []string
+	}{
+		{nil, "", []string{"billing", "billing-eu", "hello"}},
+		{regexp.MustCompile("^billing"), "", []string{"billing", "billing-eu"}},
+		{nil, "richard@piedpiper.com", []string{"hello"}},
+		{nil, " richard@PiedPiper.com", []string{"hello"}},
+		{regexp.MustCompile("^billing"), "Richard@piedpiper.com", []string{"billing"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, a := range filterAliases(aliases, tt.pattern, tt.forward) {
+			got = append(got, a.Alias)
+		}
+		if !cmp.Equal(got, tt.want) {
+			t.Errorf("filterAliases(%v, %q): %s", tt.pattern, tt.forward, cmp.Diff(tt.want, got))
+		}
+	}
+}
+
+func TestDataSourceAliasesRead_InvalidPattern(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceAliases().Schema, map[string]interface{}{
+		"domain":        "piedpiper.com",
+		"alias_pattern": "(",
+	})
+
+	c := &fakeClient{aliases: map[string]string{}}
+	if diags := dataSourceAliasesRead(context.Background(), d, c); !diags.HasError() {
+		t.Errorf("wanted an error for an invalid pattern, got %v", diags)
+	}
+}
@@ -79,7 +79,7 @@ func dataSourceDomainCheckRead(ctx context.Context, d *schema.ResourceData, meta
 	c := meta.(improvmx.Client)
 	id := d.Get("domain").(string)
 
-	check, err := c.CheckDomain(ctx, id)
+	check, err := c.Domains().Check(ctx, id)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,121 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dnsRecordTTL is the TTL suggested for every record.
+const dnsRecordTTL = 3600
+
+func dataSourceDNSRecords() *schema.Resource {
+	return &schema.Resource{
+		Description: "Returns the DNS records ImprovMX expects for a domain, ready to pass to a DNS provider with `for_each`. Covers the MX, SPF, DKIM and DMARC records.",
+
+		ReadContext: dataSourceDNSRecordsRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"records": {
+				Description: "Expected DNS records.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key":      {Type: schema.TypeString, Computed: true, Description: "Unique key for the record, e.g. `mx1` or `dkim2`. Use as the `for_each` key."},
+						"type":     {Type: schema.TypeString, Computed: true, Description: "Resource record type. Possible values are `MX`, `TXT` and `CNAME`."},
+						"name":     {Type: schema.TypeString, Computed: true, Description: "Name of the record relative to the domain, or `@` for the domain itself."},
+						"fqdn":     {Type: schema.TypeString, Computed: true, Description: "Fully qualified name of the record."},
+						"value":    {Type: schema.TypeString, Computed: true, Description: "Data for this record."},
+						"priority": {Type: schema.TypeInt, Computed: true, Description: "Priority of `MX` records, `0` for other types."},
+						"ttl":      {Type: schema.TypeInt, Computed: true, Description: "Suggested TTL in seconds."},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDNSRecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	check, err := c.Domains().Check(ctx, domain)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(domain)
+	d.Set("records", dnsRecords(domain, check))
+	return nil
+}
+
+// dnsRecords flattens the expected values of a domain check into one record
+// per value, named relative to domain.
+func dnsRecords(domain string, check *improvmx.Check) []interface{} {
+	var records []interface{}
+	add := func(key, recordType, name string, priority int, value string) {
+		fqdn := domain
+		if name != "@" {
+			fqdn = name + "." + domain
+		}
+		records = append(records, map[string]interface{}{
+			"key":      key,
+			"type":     recordType,
+			"name":     name,
+			"fqdn":     fqdn,
+			"value":    value,
+			"priority": priority,
+			"ttl":      dnsRecordTTL,
+		})
+	}
+	expected := func(r *improvmx.Record) []string {
+		if r == nil || r.Expected == nil {
+			return nil
+		}
+		return *r.Expected
+	}
+
+	for i, v := range expected(check.Mx) {
+		// values are either a bare host or `<priority> <host>`
+		priority, host := 10*(i+1), v
+		if fields := strings.Fields(v); len(fields) == 2 {
+			if p, err := strconv.Atoi(fields[0]); err == nil {
+				priority, host = p, fields[1]
+			}
+		}
+		add(fmt.Sprintf("mx%d", i+1), "MX", "@", priority, host)
+	}
+	for i, v := range expected(check.Spf) {
+		add(indexedKey("spf", i), "TXT", "@", 0, v)
+	}
+	for i, v := range expected(check.Dkim1) {
+		add(indexedKey("dkim1", i), "CNAME", "dkimprovmx1._domainkey", 0, v)
+	}
+	for i, v := range expected(check.Dkim2) {
+		add(indexedKey("dkim2", i), "CNAME", "dkimprovmx2._domainkey", 0, v)
+	}
+	for i, v := range expected(check.Dmarc) {
+		add(indexedKey("dmarc", i), "TXT", "_dmarc", 0, v)
+	}
+	return records
+}
+
+// indexedKey returns key for the first value of a record and appends the
+// index for any further values.
+func indexedKey(key string, i int) string {
+	if i == 0 {
+		return key
+	}
+	return fmt.Sprintf("%s%d", key, i+1)
+}
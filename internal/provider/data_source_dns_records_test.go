@@ -0,0 +1,69 @@
+package improvmx
+
+import (
+	"fmt"
+	"testing"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDNSRecords(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "improvmx_domain" "test" {
+					domain = "%[1]s"
+				}
+
+				data "improvmx_dns_records" "test" {
+					domain     = "%[1]s"
+					depends_on = [improvmx_domain.test]
+				}
+				`, testDomain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"data.improvmx_dns_records.test",
+						"records.*",
+						map[string]string{"key": "mx1", "type": "MX", "name": "@", "fqdn": testDomain, "priority": "10"},
+					),
+				),
+			},
+		},
+	})
+}
+
+func TestDNSRecords(t *testing.T) {
+	values := func(v ...string) *improvmx.Record {
+		rv := improvmx.RecordValues(v)
+		return &improvmx.Record{Expected: &rv}
+	}
+	check := &improvmx.Check{
+		Mx:    values("mx1.improvmx.com", "mx2.improvmx.com"),
+		Spf:   values("v=spf1 include:spf.improvmx.com ~all"),
+		Dkim1: values("dkimprovmx1.improvmx.com."),
+		Dkim2: values("dkimprovmx2.improvmx.com."),
+		Dmarc: values("v=DMARC1; p=none;"),
+	}
+
+	var got []string
+	for _, r := range dnsRecords("piedpiper.com", check) {
+		m := r.(map[string]interface{})
+		got = append(got, fmt.Sprintf("%s %s %s %d %s", m["key"], m["type"], m["fqdn"], m["priority"], m["value"]))
+	}
+	expected := []string{
+		"mx1 MX piedpiper.com 10 mx1.improvmx.com",
+		"mx2 MX piedpiper.com 20 mx2.improvmx.com",
+		"spf TXT piedpiper.com 0 v=spf1 include:spf.improvmx.com ~all",
+		"dkim1 CNAME dkimprovmx1._domainkey.piedpiper.com 0 dkimprovmx1.improvmx.com.",
+		"dkim2 CNAME dkimprovmx2._domainkey.piedpiper.com 0 dkimprovmx2.improvmx.com.",
+		"dmarc TXT _dmarc.piedpiper.com 0 v=DMARC1; p=none;",
+	}
+	if !cmp.Equal(got, expected) {
+		t.Errorf("unexpected records: %s", cmp.Diff(expected, got))
+	}
+}
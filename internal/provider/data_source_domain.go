@@ -16,6 +16,11 @@ func dataSourceDomain() *schema.Resource {
 }
 
 func dataSourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	d.SetId(d.Get("domain").(string))
-	return resourceDomainRead(ctx, d, meta)
+	domain := d.Get("domain").(string)
+	d.SetId(domain)
+	diags := resourceDomainRead(ctx, d, meta)
+	if d.Id() == "" {
+		return append(diags, diag.Errorf("domain %s not found", domain)...)
+	}
+	return diags
 }
@@ -0,0 +1,136 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceDomains() *schema.Resource {
+	return &schema.Resource{
+		Description: "Returns the domains registered with the account. Every page of results is read unless `page` is set.",
+
+		ReadContext: dataSourceDomainsRead,
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Description: "Only return domains whose name contains this value.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"is_active": {
+				Description: "Set to `true` to only return active domains.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"limit": {
+				Description:  "Number of domains requested per page.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"page": {
+				Description:  "Only return this page of results.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"include_aliases": {
+				Description: "Set to `true` to read every domain's aliases into `alias`. Requires an additional request per domain.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"domains": {
+				Description: "Domains matching the filters, sorted by name.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain":             {Type: schema.TypeString, Computed: true, Description: "Domain name."},
+						"active":             {Type: schema.TypeBool, Computed: true, Description: "True if domain is currently active."},
+						"display":            {Type: schema.TypeString, Computed: true, Description: "Domain display name."},
+						"dkim_selector":      {Type: schema.TypeString, Computed: true, Description: "DKIM selector for domain."},
+						"notification_email": {Type: schema.TypeString, Computed: true, Description: "Email to send notifications to."},
+						"webhook":            {Type: schema.TypeString, Computed: true, Description: "Endpoint to send email events to as POST requests."},
+						"whitelabel":         {Type: schema.TypeString, Computed: true, Description: "Parent domain used when displaying DNS settings."},
+						"added":              {Type: schema.TypeInt, Computed: true, Description: "Timestamp when the domain was added."},
+						"alias": {
+							Description: "Domain aliases. Only set when `include_aliases` is `true`.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"alias":   {Type: schema.TypeString, Computed: true, Description: "Alias to be redirected."},
+									"forward": {Type: schema.TypeString, Computed: true, Description: "Destination email address(es)."},
+									"id":      {Type: schema.TypeInt, Computed: true, Description: "Unique ID for alias."},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDomainsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+
+	query := &improvmx.QueryDomain{
+		Query:    d.Get("query").(string),
+		IsActive: d.Get("is_active").(bool),
+		PaginationOptions: improvmx.PaginationOptions{
+			Limit: d.Get("limit").(int),
+			Page:  d.Get("page").(int),
+		},
+	}
+	domains, err := c.Domains().List(ctx, query)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sort.Slice(*domains, func(i, j int) bool { return (*domains)[i].Domain < (*domains)[j].Domain })
+
+	includeAliases := d.Get("include_aliases").(bool)
+	domainList := make([]interface{}, len(*domains))
+	names := make([]string, len(*domains))
+	for i, domain := range *domains {
+		var aliasList []interface{}
+		if includeAliases {
+			aliases, err := c.Aliases(domain.Domain).List(ctx)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			for _, a := range *aliases {
+				aliasList = append(aliasList, map[string]interface{}{
+					"alias":   a.Alias,
+					"forward": a.Forward,
+					"id":      a.ID,
+				})
+			}
+		}
+
+		names[i] = domain.Domain
+		domainList[i] = map[string]interface{}{
+			"domain":             domain.Domain,
+			"active":             domain.Active,
+			"display":            domain.Display,
+			"dkim_selector":      domain.DkimSelector,
+			"notification_email": domain.NotificationEmail,
+			"webhook":            domain.Webhook,
+			"whitelabel":         domain.Whitelabel,
+			"added":              domain.Added,
+			"alias":              aliasList,
+		}
+	}
+
+	d.SetId(fmt.Sprint(hash(strings.Join(names, ","))))
+	d.Set("domains", domainList)
+	return nil
+}
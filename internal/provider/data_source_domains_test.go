@@ -0,0 +1,44 @@
+package improvmx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDomains(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "improvmx_domain" "test" {
+					domain = "%[1]s"
+
+					alias {
+						alias = "hello"
+						forward = "hello@piedpiper.com"
+					}
+				}
+
+				data "improvmx_domains" "test" {
+					query           = "%[1]s"
+					include_aliases = true
+					depends_on      = [improvmx_domain.test]
+				}
+				`, testDomain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.improvmx_domains.test", "domains.#", "1"),
+					resource.TestCheckResourceAttr("data.improvmx_domains.test", "domains.0.domain", testDomain),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"data.improvmx_domains.test",
+						"domains.0.alias.*",
+						map[string]string{"alias": "hello", "forward": "hello@piedpiper.com"},
+					),
+				),
+			},
+		},
+	})
+}
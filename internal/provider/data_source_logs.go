@@ -0,0 +1,192 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var logStatuses = []string{"queued", "delivered", "refused", "soft-bounce", "hard-bounce"}
+
+func dataSourceLogs() *schema.Resource {
+	return &schema.Resource{
+		Description: "Returns the recent email logs of a domain or alias, e.g. to check that mail to a new alias is delivered.",
+
+		ReadContext: dataSourceLogsRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"alias": {
+				Description: "Only return logs of emails sent to this alias.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"status": {
+				Description:  "Only return logs whose final status matches. Possible values are `queued`, `delivered`, `refused`, `soft-bounce` and `hard-bounce`.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(logStatuses, true),
+			},
+			"since": {
+				Description:  "Only return logs of emails received at or after this time, in RFC 3339 format.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"until": {
+				Description:  "Only return logs of emails received before this time, in RFC 3339 format.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"logs": {
+				Description: "Logs matching the filters, most recent first.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id":         {Type: schema.TypeString, Computed: true, Description: "Unique ID for log."},
+						"message_id": {Type: schema.TypeString, Computed: true, Description: "`Message-ID` header of the email."},
+						"created":    {Type: schema.TypeString, Computed: true, Description: "Time the email was received, in RFC 3339 format."},
+						"sender":     {Type: schema.TypeString, Computed: true, Description: "Email address of the sender."},
+						"recipient":  {Type: schema.TypeString, Computed: true, Description: "Email address the email was sent to."},
+						"forward":    {Type: schema.TypeString, Computed: true, Description: "Email address the email was forwarded to."},
+						"subject":    {Type: schema.TypeString, Computed: true, Description: "Subject of the email."},
+						"status":     {Type: schema.TypeString, Computed: true, Description: "Status of the most recent event, e.g. `delivered`."},
+						"events": {
+							Description: "Delivery events, oldest first.",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"status":  {Type: schema.TypeString, Computed: true, Description: "Status of the event, e.g. `queued`."},
+									"code":    {Type: schema.TypeInt, Computed: true, Description: "SMTP response code."},
+									"message": {Type: schema.TypeString, Computed: true, Description: "SMTP response message."},
+									"server":  {Type: schema.TypeString, Computed: true, Description: "Server the event was reported by."},
+									"created": {Type: schema.TypeString, Computed: true, Description: "Time of the event."},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLogsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	id := domain
+	query := &improvmx.QueryLog{Domain: &domain}
+	if alias := d.Get("alias").(string); alias != "" {
+		id = joinID(domain, alias)
+		query.Alias = &alias
+	}
+
+	var err error
+	filter := logFilter{status: strings.ToLower(d.Get("status").(string))}
+	if v := d.Get("since").(string); v != "" {
+		if filter.since, err = time.Parse(time.RFC3339, v); err != nil {
+			return diag.Errorf("invalid since: %s", err)
+		}
+	}
+	if v := d.Get("until").(string); v != "" {
+		if filter.until, err = time.Parse(time.RFC3339, v); err != nil {
+			return diag.Errorf("invalid until: %s", err)
+		}
+	}
+
+	logs, err := c.Logs().List(ctx, query)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var all []improvmx.Log
+	if logs != nil {
+		all = *logs
+	}
+	logList, diags := filter.apply(all)
+
+	d.SetId(id)
+	d.Set("logs", logList)
+	return diags
+}
+
+type logFilter struct {
+	status       string
+	since, until time.Time
+}
+
+// apply returns the logs matching the filter, most recent first, flattened
+// for the `logs` attribute. Logs with an unparseable timestamp are skipped
+// with a warning, so that one bad entry does not hide the others.
+func (f logFilter) apply(logs []improvmx.Log) ([]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	type entry struct {
+		log     improvmx.Log
+		created time.Time
+	}
+
+	var matched []entry
+	for _, l := range logs {
+		created, err := l.CreatedTime()
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Skipped log %s", l.ID),
+				Detail:   err.Error(),
+			})
+			continue
+		}
+		if f.status != "" && l.Status() != f.status {
+			continue
+		}
+		if !f.since.IsZero() && created.Before(f.since) {
+			continue
+		}
+		if !f.until.IsZero() && !created.Before(f.until) {
+			continue
+		}
+		matched = append(matched, entry{l, created})
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].created.After(matched[j].created) })
+
+	logList := make([]interface{}, len(matched))
+	for i, m := range matched {
+		events := make([]interface{}, len(m.log.Events))
+		for j, e := range m.log.Events {
+			events[j] = map[string]interface{}{
+				"status":  strings.ToLower(e.Status),
+				"code":    e.Code,
+				"message": e.Message,
+				"server":  e.Server,
+				"created": e.Created,
+			}
+		}
+		logList[i] = map[string]interface{}{
+			"id":         m.log.ID,
+			"message_id": m.log.MessageID,
+			"created":    m.created.Format(time.RFC3339),
+			"sender":     strings.ToLower(m.log.Sender.Email),
+			"recipient":  strings.ToLower(m.log.Recipient.Email),
+			"forward":    strings.ToLower(m.log.Forward.Email),
+			"subject":    m.log.Subject,
+			"status":     m.log.Status(),
+			"events":     events,
+		}
+	}
+	return logList, diags
+}
@@ -0,0 +1,97 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccDataSourceLogs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "improvmx_domain" "test" {
+					domain = "%[1]s"
+				}
+
+				data "improvmx_logs" "test" {
+					domain     = "%[1]s"
+					status     = "delivered"
+					depends_on = [improvmx_domain.test]
+				}
+				`, testDomain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.improvmx_logs.test", "logs.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestLogFilter(t *testing.T) {
+	logs := []improvmx.Log{
+		{ID: "1", CreatedRaw: "1617272400", Events: []improvmx.LogEvent{{Status: "QUEUED"}, {Status: "DELIVERED"}}},
+		{ID: "2", CreatedRaw: "1617272500", Events: []improvmx.LogEvent{{Status: "QUEUED"}, {Status: "HARD-BOUNCE"}}},
+		{ID: "3", CreatedRaw: "1617272600", Events: []improvmx.LogEvent{{Status: "DELIVERED"}}},
+	}
+
+	tests := []struct {
+		filter logFilter
+		want   []string
+	}{
+		{logFilter{}, []string{"3", "2", "1"}},
+		{logFilter{status: "delivered"}, []string{"3", "1"}},
+		{logFilter{since: time.Unix(1617272500, 0)}, []string{"3", "2"}},
+		{logFilter{until: time.Unix(1617272500, 0)}, []string{"1"}},
+		{logFilter{status: "delivered", since: time.Unix(1617272450, 0)}, []string{"3"}},
+	}
+	for _, tt := range tests {
+		logList, diags := tt.filter.apply(logs)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		var got []string
+		for _, l := range logList {
+			got = append(got, l.(map[string]interface{})["id"].(string))
+		}
+		if !cmp.Equal(got, tt.want) {
+			t.Errorf("unexpected logs for %+v: %s", tt.filter, cmp.Diff(tt.want, got))
+		}
+	}
+}
+
+func TestLogFilter_InvalidCreated(t *testing.T) {
+	logs := []improvmx.Log{
+		{ID: "1", CreatedRaw: "1617272400"},
+		{ID: "2", CreatedRaw: "yesterday"},
+	}
+
+	logList, diags := logFilter{}.apply(logs)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("wanted a warning about the skipped log, got %v", diags)
+	}
+	if len(logList) != 1 || logList[0].(map[string]interface{})["id"] != "1" {
+		t.Errorf("wanted only the valid log, got %v", logList)
+	}
+}
+
+func TestDataSourceLogsRead_InvalidSince(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceLogs().Schema, map[string]interface{}{
+		"domain": "piedpiper.com",
+		"since":  "yesterday",
+	})
+
+	if diags := dataSourceLogsRead(context.Background(), d, &fakeClient{}); !diags.HasError() {
+		t.Errorf("wanted an error for an invalid since, got %v", diags)
+	}
+}
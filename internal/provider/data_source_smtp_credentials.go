@@ -0,0 +1,75 @@
+package improvmx
+
+import (
+	"context"
+	"sort"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceSMTPCredentials() *schema.Resource {
+	return &schema.Resource{
+		Description: "Returns the SMTP credentials of a domain and the number of emails sent with each of them today. Combine with the `daily_send` limit of `improvmx_account` to check how close a domain is to its sending quota.",
+
+		ReadContext: dataSourceSMTPCredentialsRead,
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain name.",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"credentials": {
+				Description: "SMTP credentials of the domain, sorted by username.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"username": {Type: schema.TypeString, Computed: true, Description: "Username of the credential."},
+						"usage":    {Type: schema.TypeInt, Computed: true, Description: "Number of emails sent with the credential today."},
+						"created":  {Type: schema.TypeInt, Computed: true, Description: "Timestamp when the credential was created."},
+					},
+				},
+			},
+			"total_usage": {
+				Description: "Number of emails sent with all of the domain's credentials today.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourceSMTPCredentialsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	creds, err := c.Credentials(domain).List(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	credentials := []improvmx.SMTPCredential{}
+	if creds != nil {
+		credentials = *creds
+	}
+	sort.Slice(credentials, func(i, j int) bool { return credentials[i].Username < credentials[j].Username })
+
+	total := 0
+	credList := make([]interface{}, len(credentials))
+	for i, cred := range credentials {
+		total += cred.Usage
+		credList[i] = map[string]interface{}{
+			"username": cred.Username,
+			"usage":    cred.Usage,
+			"created":  cred.Created,
+		}
+	}
+
+	d.SetId(domain)
+	d.Set("credentials", credList)
+	d.Set("total_usage", total)
+	return nil
+}
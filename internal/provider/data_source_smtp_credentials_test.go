@@ -0,0 +1,40 @@
+package improvmx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSMTPCredentials(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+				resource "improvmx_domain" "test" {
+					domain = "%[1]s"
+				}
+
+				resource "improvmx_smtp_credential" "test" {
+					domain   = improvmx_domain.test.domain
+					username = "richard"
+					password = "correct-horse-battery-staple"
+				}
+
+				data "improvmx_smtp_credentials" "test" {
+					domain     = "%[1]s"
+					depends_on = [improvmx_smtp_credential.test]
+				}
+				`, testDomain),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.improvmx_smtp_credentials.test", "credentials.#", "1"),
+					resource.TestCheckResourceAttr("data.improvmx_smtp_credentials.test", "credentials.0.username", "richard"),
+					resource.TestCheckResourceAttrSet("data.improvmx_smtp_credentials.test", "total_usage"),
+				),
+			},
+		},
+	})
+}
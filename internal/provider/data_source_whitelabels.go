@@ -0,0 +1,48 @@
+package improvmx
+
+import (
+	"context"
+	"sort"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceWhitelabels() *schema.Resource {
+	return &schema.Resource{
+		Description: "Returns the whitelabels available to the account, for use as `improvmx_domain.whitelabel`.",
+
+		ReadContext: dataSourceWhitelabelsRead,
+
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Description: "Names of the account's whitelabels, sorted alphabetically.",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceWhitelabelsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+
+	whitelabels, err := c.Account().Whitelabels(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := []string{}
+	if whitelabels != nil {
+		for _, w := range *whitelabels {
+			names = append(names, w.Name)
+		}
+	}
+	sort.Strings(names)
+
+	d.SetId("whitelabels")
+	d.Set("names", names)
+	return nil
+}
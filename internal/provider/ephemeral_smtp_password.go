@@ -0,0 +1,76 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	ephemeralschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultPasswordLength is the length of generated SMTP passwords unless
+// configured otherwise.
+const defaultPasswordLength = 32
+
+type smtpPasswordEphemeralResource struct{}
+
+type smtpPasswordModel struct {
+	Length types.Int64  `tfsdk:"length"`
+	Result types.String `tfsdk:"result"`
+}
+
+func newSMTPPasswordEphemeralResource() ephemeral.EphemeralResource {
+	return &smtpPasswordEphemeralResource{}
+}
+
+func (r *smtpPasswordEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_smtp_password"
+}
+
+func (r *smtpPasswordEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = ephemeralschema.Schema{
+		MarkdownDescription: "Generates a random password for an SMTP credential. The password mixes lower case letters, upper case letters and digits, and is never stored in the plan or state. Requires Terraform 1.10 or later.",
+
+		Attributes: map[string]ephemeralschema.Attribute{
+			"length": ephemeralschema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Length of the password. Defaults to `%d`, and must be at least `%d`.", defaultPasswordLength, improvmx.MinPasswordLength),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(improvmx.MinPasswordLength),
+				},
+			},
+			"result": ephemeralschema.StringAttribute{
+				MarkdownDescription: "Generated password.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+func (r *smtpPasswordEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data smtpPasswordModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	length := int64(defaultPasswordLength)
+	if !data.Length.IsNull() && !data.Length.IsUnknown() {
+		length = data.Length.ValueInt64()
+	}
+
+	password, err := improvmx.GeneratePassword(int(length))
+	if err != nil {
+		resp.Diagnostics.AddError("Error generating SMTP password", err.Error())
+		return
+	}
+
+	data.Length = types.Int64Value(length)
+	data.Result = types.StringValue(password)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
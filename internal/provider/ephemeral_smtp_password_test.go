@@ -0,0 +1,69 @@
+package improvmx
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSMTPPasswordEphemeralResource(t *testing.T) {
+	ctx := context.Background()
+	server, err := protoV5ProviderFactories["improvmx"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	typ := schemaResp.EphemeralResourceSchemas["improvmx_smtp_password"].ValueType()
+
+	for _, length := range []int64{0, 20} {
+		lengthValue := tftypes.NewValue(tftypes.Number, nil)
+		if length > 0 {
+			lengthValue = tftypes.NewValue(tftypes.Number, big.NewFloat(float64(length)))
+		}
+		config, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, map[string]tftypes.Value{
+			"length": lengthValue,
+			"result": tftypes.NewValue(tftypes.String, nil),
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := server.OpenEphemeralResource(ctx, &tfprotov5.OpenEphemeralResourceRequest{
+			TypeName: "improvmx_smtp_password",
+			Config:   &config,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range resp.Diagnostics {
+			t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+
+		result, err := resp.Result.Unmarshal(typ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var values map[string]tftypes.Value
+		var password string
+		if err := result.As(&values); err != nil {
+			t.Fatal(err)
+		}
+		if err := values["result"].As(&password); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := length
+		if expected == 0 {
+			expected = defaultPasswordLength
+		}
+		if int64(len(password)) != expected {
+			t.Errorf("unexpected password length: wanted %d, got %d", expected, len(password))
+		}
+	}
+}
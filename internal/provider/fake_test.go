@@ -0,0 +1,157 @@
+package improvmx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+)
+
+// fakeClient keeps a single domain, its aliases and its logs in memory,
+// recording the changes made to them. It implements the services used by
+// the provider; the remaining methods of improvmx.Client are the deprecated
+// ones, which panic if called.
+type fakeClient struct {
+	improvmx.Client
+
+	exists   bool
+	settings improvmx.Domain
+	updates  int
+	limits   *improvmx.AccountLimit
+	logs     []improvmx.Log
+
+	// aliases maps the names of aliases to their forward, stored the way
+	// the API returns it, with the destinations normalized and reordered
+	aliases map[string]string
+	deleted []string
+	bulk    []string
+
+	// failAliases lists the aliases that fail to be created
+	failAliases map[string]bool
+}
+
+func (c *fakeClient) Account() improvmx.AccountService            { return fakeAccountService{c} }
+func (c *fakeClient) Domains() improvmx.DomainService             { return fakeDomainService{c} }
+func (c *fakeClient) Aliases(domain string) improvmx.AliasService { return fakeAliasService{c} }
+func (c *fakeClient) Logs() improvmx.LogService                   { return fakeLogService{c} }
+
+type fakeAccountService struct{ *fakeClient }
+
+func (s fakeAccountService) Get(ctx context.Context) (*improvmx.Account, error) {
+	return &improvmx.Account{Limits: s.limits}, nil
+}
+
+func (s fakeAccountService) Whitelabels(ctx context.Context) (*[]improvmx.Whitelabel, error) {
+	return &[]improvmx.Whitelabel{}, nil
+}
+
+type fakeDomainService struct{ *fakeClient }
+
+func (s fakeDomainService) List(ctx context.Context, query *improvmx.QueryDomain) (*[]improvmx.Domain, error) {
+	domains := []improvmx.Domain{}
+	if s.exists {
+		domain, _ := s.Get(ctx, s.settings.Domain)
+		domains = append(domains, *domain)
+	}
+	return &domains, nil
+}
+
+// Add reports an existing domain as a bad request, as the API does.
+func (s fakeDomainService) Add(ctx context.Context, d *improvmx.Domain) (*improvmx.Domain, error) {
+	if s.exists {
+		return nil, &improvmx.Error{StatusCode: http.StatusBadRequest}
+	}
+	s.exists = true
+	s.settings = *d
+	return d, nil
+}
+
+func (s fakeDomainService) Get(ctx context.Context, domain string) (*improvmx.Domain, error) {
+	aliases, _ := s.Aliases(domain).List(ctx)
+	return &improvmx.Domain{
+		Domain:            domain,
+		NotificationEmail: s.settings.NotificationEmail,
+		Webhook:           s.settings.Webhook,
+		Whitelabel:        s.settings.Whitelabel,
+		Aliases:           aliases,
+	}, nil
+}
+
+func (s fakeDomainService) Update(ctx context.Context, d *improvmx.Domain) (*improvmx.Domain, error) {
+	s.updates++
+	s.settings = *d
+	return d, nil
+}
+
+func (s fakeDomainService) Delete(ctx context.Context, d *improvmx.Domain) error {
+	s.exists = false
+	s.aliases = map[string]string{}
+	return nil
+}
+
+func (s fakeDomainService) Check(ctx context.Context, domain string) (*improvmx.Check, error) {
+	return testCheck(true), nil
+}
+
+type fakeAliasService struct{ *fakeClient }
+
+func (s fakeAliasService) List(ctx context.Context) (*[]improvmx.Alias, error) {
+	var aliases []improvmx.Alias
+	for name, forward := range s.aliases {
+		aliases = append(aliases, improvmx.Alias{Alias: name, Forward: forward})
+	}
+	return &aliases, nil
+}
+
+func (s fakeAliasService) Get(ctx context.Context, alias string) (*improvmx.Alias, error) {
+	forward, ok := s.aliases[alias]
+	if !ok {
+		return nil, &improvmx.Error{StatusCode: http.StatusNotFound}
+	}
+	return &improvmx.Alias{Alias: alias, Forward: forward}, nil
+}
+
+// Create reports an existing alias as a bad request, as the API does.
+func (s fakeAliasService) Create(ctx context.Context, a *improvmx.Alias) (*improvmx.Alias, error) {
+	if s.failAliases[a.Alias] {
+		return nil, errors.New("alias rejected")
+	}
+	if _, ok := s.aliases[a.Alias]; ok {
+		return nil, &improvmx.Error{StatusCode: http.StatusBadRequest}
+	}
+	return s.Update(ctx, a)
+}
+
+func (s fakeAliasService) Update(ctx context.Context, a *improvmx.Alias) (*improvmx.Alias, error) {
+	s.aliases[a.Alias] = strings.Join(a.Destinations(), ",")
+	return &improvmx.Alias{Alias: a.Alias, Forward: s.aliases[a.Alias]}, nil
+}
+
+func (s fakeAliasService) Delete(ctx context.Context, a *improvmx.Alias) error {
+	s.deleted = append(s.deleted, a.Alias)
+	delete(s.aliases, a.Alias)
+	return nil
+}
+
+// Bulk records each alias of the request as "<behavior> <alias>".
+func (s fakeAliasService) Bulk(ctx context.Context, behavior improvmx.BulkAliasBehavior, aliases []improvmx.Alias) (*improvmx.BulkAliasResult, error) {
+	for _, a := range aliases {
+		s.bulk = append(s.bulk, fmt.Sprintf("%s %s", behavior, a.Alias))
+		switch behavior {
+		case improvmx.BulkAliasDelete:
+			delete(s.aliases, a.Alias)
+		default:
+			s.aliases[a.Alias] = strings.Join(a.Destinations(), ",")
+		}
+	}
+	return &improvmx.BulkAliasResult{}, nil
+}
+
+type fakeLogService struct{ *fakeClient }
+
+func (s fakeLogService) List(ctx context.Context, query *improvmx.QueryLog) (*[]improvmx.Log, error) {
+	return &s.logs, nil
+}
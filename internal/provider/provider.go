@@ -3,6 +3,7 @@ package improvmx
 import (
 	"context"
 	"log"
+	"time"
 
 	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -43,13 +44,32 @@ func New(version string) func() *schema.Provider {
 					Sensitive:   true,
 					DefaultFunc: schema.EnvDefaultFunc("IMPROVMX_API_KEY", nil),
 				},
+				"cache_ttl": {
+					Type:        schema.TypeInt,
+					Description: "Number of seconds to cache responses from ImprovMX's read-only endpoints for. Concurrent reads of the same object are coalesced into a single request while caching is enabled. Defaults to `0`, which disables caching.",
+					Optional:    true,
+					Default:     0,
+				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
-				"improvmx_domain": dataSourceDomain(),
-				"improvmx_check":  dataSourceDomainCheck(),
+				"improvmx_account":          dataSourceAccount(),
+				"improvmx_aliases":          dataSourceAliases(),
+				"improvmx_domain":           dataSourceDomain(),
+				"improvmx_domains":          dataSourceDomains(),
+				"improvmx_check":            dataSourceDomainCheck(),
+				"improvmx_dns_records":      dataSourceDNSRecords(),
+				"improvmx_logs":             dataSourceLogs(),
+				"improvmx_smtp_credentials": dataSourceSMTPCredentials(),
+				"improvmx_whitelabels":      dataSourceWhitelabels(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"improvmx_domain": resourceDomain(),
+				"improvmx_domain":              resourceDomain(),
+				"improvmx_alias":               resourceAlias(),
+				"improvmx_catch_all":           resourceCatchAll(),
+				"improvmx_domain_aliases":      resourceDomainAliases(),
+				"improvmx_domain_verification": resourceDomainVerification(),
+				"improvmx_rule":                resourceRule(),
+				"improvmx_smtp_credential":     resourceSMTPCredential(),
 			},
 		}
 
@@ -63,11 +83,13 @@ func configure(version string, p *schema.Provider) func(context.Context, *schema
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		url := d.Get("base_url").(string)
 		apiKey := d.Get("api_key").(string)
+		cacheTTL := d.Get("cache_ttl").(int)
 		userAgent := p.UserAgent("terraform-provider-improvmx", version)
 		client := improvmx.NewClient(url, apiKey, log.Writer())
 		if err := client.SetUserAgent(userAgent); err != nil {
 			diag.FromErr(err)
 		}
+		client.SetCacheTTL(time.Duration(cacheTTL) * time.Second)
 		return client, nil
 	}
 }
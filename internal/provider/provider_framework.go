@@ -0,0 +1,95 @@
+package improvmx
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+// frameworkProvider serves the parts of the provider that SDKv2 cannot, such
+// as ephemeral resources. It is muxed with the SDKv2 provider, so both must
+// declare the same provider schema.
+type frameworkProvider struct {
+	version string
+}
+
+var _ provider.ProviderWithEphemeralResources = &frameworkProvider{}
+
+func NewFramework(version string) func() provider.Provider {
+	return func() provider.Provider {
+		return &frameworkProvider{version: version}
+	}
+}
+
+// NewMuxServer combines the SDKv2 and framework providers into a single
+// provider server.
+func NewMuxServer(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
+	server, err := tf5muxserver.NewMuxServer(ctx,
+		New(version)().GRPCProvider,
+		providerserver.NewProtocol5(NewFramework(version)()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return server.ProviderServer, nil
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "improvmx"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	// mirror the SDKv2 schema, including how it makes `api_key` optional when
+	// its environment variable is set
+	sdk := New(p.version)().Schema
+	apiKeyRequired := true
+	if v, err := sdk["api_key"].DefaultFunc(); err != nil || v != nil {
+		apiKeyRequired = false
+	}
+
+	resp.Schema = providerschema.Schema{
+		Attributes: map[string]providerschema.Attribute{
+			"base_url": providerschema.StringAttribute{
+				MarkdownDescription: sdk["base_url"].Description,
+				Optional:            true,
+			},
+			"api_key": providerschema.StringAttribute{
+				MarkdownDescription: sdk["api_key"].Description,
+				Required:            apiKeyRequired,
+				Optional:            !apiKeyRequired,
+				Sensitive:           true,
+			},
+			"cache_ttl": providerschema.Int64Attribute{
+				MarkdownDescription: sdk["cache_ttl"].Description,
+				Optional:            true,
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	// the SDKv2 provider configures the API client, none of the framework
+	// resources need it yet
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return nil
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (p *frameworkProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newSMTPPasswordEphemeralResource,
+	}
+}
@@ -1,11 +1,15 @@
 package improvmx
 
 import (
+	"context"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -14,6 +18,18 @@ const testDomain = "terraform-provider-improvmx.com"
 var improvmxClient improvmx.Client
 var providerFactories map[string]func() (*schema.Provider, error)
 
+// protoV5ProviderFactories serve the muxed provider, for tests that use
+// resources of the framework provider.
+var protoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	"improvmx": func() (tfprotov5.ProviderServer, error) {
+		server, err := NewMuxServer(context.Background(), "dev")
+		if err != nil {
+			return nil, err
+		}
+		return server(), nil
+	},
+}
+
 func init() {
 	improvmxClient = improvmx.NewClient(
 		"https://api.improvmx.com/v3",
@@ -57,6 +73,49 @@ func TestProvider(t *testing.T) {
 	}
 }
 
+// TestProvider_Docs checks that every documented data source and resource is
+// served by the provider.
+func TestProvider_Docs(t *testing.T) {
+	p := New("dev")()
+	for dir, served := range map[string]map[string]*schema.Resource{
+		"data-sources": p.DataSourcesMap,
+		"resources":    p.ResourcesMap,
+	} {
+		docs, err := filepath.Glob(filepath.Join("..", "..", "docs", dir, "*.md"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(docs) == 0 {
+			t.Fatalf("no docs found for %s", dir)
+		}
+		for _, doc := range docs {
+			name := "improvmx_" + strings.TrimSuffix(filepath.Base(doc), ".md")
+			if _, ok := served[name]; !ok {
+				t.Errorf("%s is documented in docs/%s, but not served by the provider", name, dir)
+			}
+		}
+	}
+}
+
+func TestMuxServer_ProviderSchema(t *testing.T) {
+	// the providers' schemas only match if api_key is required by both
+	for _, apiKey := range []string{"", "test"} {
+		t.Setenv("IMPROVMX_API_KEY", apiKey)
+
+		server, err := protoV5ProviderFactories["improvmx"]()
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := server.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, d := range resp.Diagnostics {
+			t.Errorf("unexpected diagnostic with IMPROVMX_API_KEY=%q: %s: %s", apiKey, d.Summary, d.Detail)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if err := os.Getenv("IMPROVMX_API_KEY"); err == "" {
 		t.Fatal("IMPROVMX_API_KEY must be set for acceptance tests")
@@ -0,0 +1,282 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceAlias() *schema.Resource {
+	return &schema.Resource{
+		Description: "ImprovMX alias resource. Manages a single alias of a domain, independently of the `improvmx_domain` resource. Existing aliases must be imported, except for the catch-all alias `*` ImprovMX creates with every domain, which is adopted.",
+
+		CreateContext: resourceAliasCreate,
+		ReadContext:   resourceAliasRead,
+		UpdateContext: resourceAliasUpdate,
+		DeleteContext: resourceAliasDelete,
+
+		CustomizeDiff: customizeForwardDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAliasImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"alias": {
+				Description: "Alias to be used in front of your domain, e.g. “contact”, “info”, etc. Use `*` for the domain's catch-all alias.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"forward":  forwardSchema,
+			"forwards": forwardsSchema,
+			"alias_id": {
+				Description: "Unique ID for alias.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceAliasCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	if err := checkDestinationLimit(ctx, c, forwardFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	input := aliasFromResourceData(d)
+	if input.Alias != "*" {
+		// other aliases are never taken over, as their destinations may be
+		// managed elsewhere
+		alias, err := c.Aliases(domain).Create(ctx, input)
+		if err != nil {
+			if _, getErr := c.Aliases(domain).Get(ctx, input.Alias); getErr == nil {
+				return diag.Errorf("alias %s already exists: import it with `terraform import` to manage it", joinID(domain, input.Alias))
+			}
+			return diag.FromErr(err)
+		}
+		d.SetId(joinID(domain, alias.Alias))
+		return resourceAliasRead(ctx, d, meta)
+	}
+
+	// adopt the catch-all alias, which ImprovMX creates with every domain
+	alias, result, err := improvmx.EnsureAlias(ctx, c.Aliases(domain), input)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(joinID(domain, alias.Alias))
+	if result != improvmx.EnsureCreated {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Adopted existing alias %s", d.Id()),
+			Detail:   "The catch-all alias already existed in ImprovMX and is now managed by Terraform.",
+		})
+	}
+
+	return append(diags, resourceAliasRead(ctx, d, meta)...)
+}
+
+func resourceAliasRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain, name, err := splitID(d.Id(), "alias")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	alias, err := c.Aliases(domain).Get(ctx, name)
+	if err != nil {
+		return removeIfNotFound(d, err)
+	}
+
+	d.Set("domain", domain)
+	d.Set("alias", alias.Alias)
+	setForward(d, alias.Forward)
+	d.Set("alias_id", alias.ID)
+
+	return nil
+}
+
+func resourceAliasUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	if err := checkDestinationLimit(ctx, c, forwardFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := c.Aliases(domain).Update(ctx, aliasFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAliasRead(ctx, d, meta)
+}
+
+func resourceAliasDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	if err := ignoreNotFound(c.Aliases(domain).Delete(ctx, aliasFromResourceData(d))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAliasImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	domain, name, err := splitID(d.Id(), "alias")
+	if err != nil {
+		return nil, err
+	}
+	d.Set("domain", domain)
+	d.Set("alias", name)
+	return []*schema.ResourceData{d}, nil
+}
+
+func aliasFromResourceData(d *schema.ResourceData) *improvmx.Alias {
+	return &improvmx.Alias{
+		Alias:   d.Get("alias").(string),
+		Forward: forwardFromResourceData(d),
+	}
+}
+
+// forwardSchema and forwardsSchema let the destinations of an alias be set
+// either as a comma-separated string or as a set.
+var forwardSchema = &schema.Schema{
+	Description:      "Destination email or endpoint to forward emails to. Separate multiple destinations with commas. Exactly one of `forward` or `forwards` must be set.",
+	Type:             schema.TypeString,
+	Optional:         true,
+	Computed:         true,
+	ExactlyOneOf:     []string{"forward", "forwards"},
+	DiffSuppressFunc: suppressEquivalentForward,
+}
+
+var forwardsSchema = &schema.Schema{
+	Description:  "Destination emails or endpoints to forward emails to. Destinations are compared without surrounding spaces and with the domains of emails in lower case. Exactly one of `forward` or `forwards` must be set.",
+	Type:         schema.TypeSet,
+	Optional:     true,
+	Computed:     true,
+	ExactlyOneOf: []string{"forward", "forwards"},
+	Elem:         &schema.Schema{Type: schema.TypeString},
+	Set:          hashDestination,
+}
+
+// hashDestination hashes a destination in `forwards` by its normalized form.
+func hashDestination(v interface{}) int {
+	return hash(improvmx.NormalizeDestination(v.(string)))
+}
+
+// suppressEquivalentForward ignores changes to `forward` that only reorder,
+// repeat or reformat destinations.
+func suppressEquivalentForward(k, old, new string, d *schema.ResourceData) bool {
+	return slices.Equal(improvmx.Alias{Forward: old}.Destinations(), improvmx.Alias{Forward: new}.Destinations())
+}
+
+// customizeForwardDiff marks `forward` or `forwards` as computed when the
+// destinations of the other one change, as both are read back from the same
+// destinations.
+func customizeForwardDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if old, new := d.GetChange("forward"); !suppressEquivalentForward("forward", old.(string), new.(string), nil) {
+		return d.SetNewComputed("forwards")
+	}
+	if old, new := d.GetChange("forwards"); joinSetDestinations(old) != joinSetDestinations(new) {
+		return d.SetNewComputed("forward")
+	}
+	return nil
+}
+
+// joinSetDestinations joins the destinations in a set of `forwards`.
+func joinSetDestinations(v interface{}) string {
+	var list []interface{}
+	switch v := v.(type) {
+	case *schema.Set:
+		list = v.List()
+	case []interface{}:
+		list = v
+	}
+	var dests []string
+	for _, dest := range list {
+		if dest, ok := dest.(string); ok {
+			dests = append(dests, dest)
+		}
+	}
+	return improvmx.JoinDestinations(dests)
+}
+
+// forwardFromResourceData returns the destinations of an alias as a
+// comma-separated string, taken from `forwards` if it is configured or has
+// changed and from `forward` otherwise.
+func forwardFromResourceData(d *schema.ResourceData) string {
+	forwards, ok := d.GetOk("forwards")
+	if ok && (d.Id() == "" || d.HasChange("forwards")) {
+		return joinSetDestinations(forwards)
+	}
+	return d.Get("forward").(string)
+}
+
+// setForward stores the destinations of an alias in `forward` and
+// `forwards`, keeping the values in state when they are equivalent so that
+// how the destinations are written in the configuration is preserved.
+func setForward(d *schema.ResourceData, forward string) {
+	dests := improvmx.Alias{Forward: forward}.Destinations()
+	if !slices.Equal(improvmx.Alias{Forward: d.Get("forward").(string)}.Destinations(), dests) {
+		d.Set("forward", forward)
+	}
+
+	if joinSetDestinations(d.Get("forwards")) != improvmx.JoinDestinations(dests) {
+		d.Set("forwards", dests)
+	}
+}
+
+// checkDestinationLimit returns an error if forward has more destinations
+// than the plan of the account allows. Single destinations are always
+// allowed, which saves looking up the account.
+func checkDestinationLimit(ctx context.Context, c improvmx.Client, forward string) error {
+	n := len(improvmx.Alias{Forward: forward}.Destinations())
+	if n <= 1 {
+		return nil
+	}
+	account, err := c.Account().Get(ctx)
+	if err != nil {
+		return err
+	}
+	if l := account.Limits; l != nil && l.Destinations > 0 && n > l.Destinations {
+		return fmt.Errorf("alias forwards to %d destinations, but the account allows at most %d", n, l.Destinations)
+	}
+	return nil
+}
+
+// checkAliasesDestinationLimit checks the alias with the most destinations
+// against the plan of the account, so that the account is looked up at most
+// once.
+func checkAliasesDestinationLimit(ctx context.Context, c improvmx.Client, aliases *[]improvmx.Alias) error {
+	if aliases == nil {
+		return nil
+	}
+	var most improvmx.Alias
+	n := 0
+	for _, a := range *aliases {
+		if m := len(a.Destinations()); m > n {
+			most, n = a, m
+		}
+	}
+	if err := checkDestinationLimit(ctx, c, most.Forward); err != nil {
+		return fmt.Errorf("alias %s: %w", most.Alias, err)
+	}
+	return nil
+}
@@ -0,0 +1,265 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccAliasConfig(forward string) string {
+	return fmt.Sprintf(`
+		resource "improvmx_domain" "test" {
+			domain = "%[1]s"
+		}
+
+		resource "improvmx_alias" "test" {
+			domain  = improvmx_domain.test.domain
+			alias   = "billing"
+			forward = "%[2]s"
+		}
+	`, testDomain, forward)
+}
+
+func TestAccResourceAlias(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAliasConfig("billing@piedpiper.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("improvmx_alias.test", "id", testDomain+"/billing"),
+					resource.TestCheckResourceAttr("improvmx_alias.test", "forward", "billing@piedpiper.com"),
+					resource.TestMatchResourceAttr("improvmx_alias.test", "alias_id", regexp.MustCompile(`\d+`)),
+				),
+			},
+			{
+				// forward is updated in place
+				Config: testAccAliasConfig("accounts@piedpiper.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("improvmx_alias.test", "forward", "accounts@piedpiper.com"),
+				),
+			},
+			{
+				ResourceName:      "improvmx_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// alias deleted outside of Terraform is planned for re-creation
+				PreConfig: func() {
+					improvmxClient.Aliases(testDomain).Delete(context.Background(), &improvmx.Alias{Alias: "billing"})
+				},
+				Config:             testAccAliasConfig("accounts@piedpiper.com"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+		CheckDestroy: func(s *terraform.State) error {
+			for _, rs := range s.RootModule().Resources {
+				if rs.Type != "improvmx_alias" {
+					continue
+				}
+				domain, name, _ := splitID(rs.Primary.ID, "alias")
+				aliases, err := improvmxClient.Aliases(domain).List(context.Background())
+				if err != nil {
+					continue
+				}
+				for _, a := range *aliases {
+					if a.Alias == name {
+						return fmt.Errorf("alias '%s' still available after destroy", rs.Primary.ID)
+					}
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func TestResourceAliasCreate_Existing(t *testing.T) {
+	c := &fakeClient{aliases: map[string]string{
+		"*":     "richard@piedpiper.com",
+		"hello": "richard@piedpiper.com",
+	}}
+
+	// existing aliases are not taken over
+	d := schema.TestResourceDataRaw(t, resourceAlias().Schema, map[string]interface{}{
+		"domain":  "piedpiper.com",
+		"alias":   "hello",
+		"forward": "jared@piedpiper.com",
+	})
+	diags := resourceAliasCreate(context.Background(), d, c)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "terraform import") {
+		t.Errorf("wanted an error suggesting import, got %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("wanted alias not to be added to state, got ID %q", d.Id())
+	}
+	if f := c.aliases["hello"]; f != "richard@piedpiper.com" {
+		t.Errorf("wanted existing forward kept, got %q", f)
+	}
+
+	// except for the catch-all alias every domain starts with
+	d = schema.TestResourceDataRaw(t, resourceAlias().Schema, map[string]interface{}{
+		"domain":  "piedpiper.com",
+		"alias":   "*",
+		"forward": "jared@piedpiper.com",
+	})
+	diags = resourceAliasCreate(context.Background(), d, c)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("wanted a warning about adopting the alias, got %v", diags)
+	}
+	if f := d.Get("forward"); f != "jared@piedpiper.com" {
+		t.Errorf("wanted catch-all forward updated, got %q", f)
+	}
+}
+
+func TestResourceAliasRead_Missing(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAlias().Schema, map[string]interface{}{})
+	d.SetId("piedpiper.com/hello")
+
+	c := &fakeClient{aliases: map[string]string{}}
+	if diags := resourceAliasRead(context.Background(), d, c); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("wanted missing alias removed from state, got ID %q", d.Id())
+	}
+}
+
+func TestResourceAlias_Forwards(t *testing.T) {
+	ctx := context.Background()
+	c := &fakeClient{aliases: map[string]string{}, limits: &improvmx.AccountLimit{Destinations: 2}}
+
+	p := New("dev")()
+	p.ConfigureContextFunc = func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return c, nil
+	}
+	server := schema.NewGRPCProviderServer(p)
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	resourceType := schemaResp.ResourceSchemas["improvmx_alias"].ValueType().(tftypes.Object)
+
+	providerConfig := testDynamicValue(t, providerType, map[string]tftypes.Value{
+		"api_key": tftypes.NewValue(tftypes.String, "test"),
+	})
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: &providerConfig})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNoDiagnostics(t, configureResp.Diagnostics)
+
+	config := func(name, attr string, value tftypes.Value) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"domain": tftypes.NewValue(tftypes.String, "piedpiper.com"),
+			"alias":  tftypes.NewValue(tftypes.String, name),
+			attr:     value,
+		}
+	}
+	forwards := func(dests ...string) tftypes.Value {
+		var values []tftypes.Value
+		for _, dest := range dests {
+			values = append(values, tftypes.NewValue(tftypes.String, dest))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+	}
+
+	// plan plans config against prior, keeping the prior value of attributes
+	// missing from config as Terraform does for computed attributes
+	plan := func(prior tftypes.Value, values map[string]tftypes.Value) (*tfprotov5.PlanResourceChangeResponse, tfprotov5.DynamicValue) {
+		cfg := testDynamicValue(t, resourceType, values)
+		// copy the prior values, as As shares the map of prior
+		var priorValues map[string]tftypes.Value
+		if !prior.IsNull() {
+			prior.As(&priorValues)
+		}
+		proposedValues := map[string]tftypes.Value{}
+		for name, v := range priorValues {
+			proposedValues[name] = v
+		}
+		for name, v := range values {
+			proposedValues[name] = v
+		}
+		proposed := testDynamicValue(t, resourceType, proposedValues)
+		priorState, _ := tfprotov5.NewDynamicValue(resourceType, prior)
+
+		resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "improvmx_alias",
+			PriorState:       &priorState,
+			ProposedNewState: &proposed,
+			Config:           &cfg,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNoDiagnostics(t, resp.Diagnostics)
+		return resp, cfg
+	}
+	apply := func(prior tftypes.Value, values map[string]tftypes.Value) (tftypes.Value, []*tfprotov5.Diagnostic) {
+		planResp, cfg := plan(prior, values)
+		priorState, _ := tfprotov5.NewDynamicValue(resourceType, prior)
+		resp, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+			TypeName:       "improvmx_alias",
+			PriorState:     &priorState,
+			PlannedState:   planResp.PlannedState,
+			Config:         &cfg,
+			PlannedPrivate: planResp.PlannedPrivate,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		state, err := resp.NewState.Unmarshal(resourceType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return state, resp.Diagnostics
+	}
+	noChanges := func(prior tftypes.Value, values map[string]tftypes.Value) {
+		t.Helper()
+		planResp, _ := plan(prior, values)
+		planned, err := planResp.PlannedState.Unmarshal(resourceType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !planned.Equal(prior) {
+			t.Errorf("unexpected changes planned: %v", planned)
+		}
+	}
+	null := tftypes.NewValue(resourceType, nil)
+
+	// destinations are normalized, and their order does not matter
+	hello := config("hello", "forwards", forwards(" B@Y.COM", "a@x.com"))
+	state, diags := apply(null, hello)
+	testNoDiagnostics(t, diags)
+	if c.aliases["hello"] != "B@y.com,a@x.com" {
+		t.Errorf("unexpected forward after create: %q", c.aliases["hello"])
+	}
+	noChanges(state, hello)
+	noChanges(state, config("hello", "forwards", forwards("a@x.com", "B@y.com")))
+
+	contact := config("contact", "forward", tftypes.NewValue(tftypes.String, "b@y.com, a@x.com"))
+	state, diags = apply(null, contact)
+	testNoDiagnostics(t, diags)
+	noChanges(state, contact)
+	noChanges(state, config("contact", "forward", tftypes.NewValue(tftypes.String, "a@x.com,b@y.com,a@x.com")))
+
+	// the number of destinations is limited by the plan of the account
+	_, diags = apply(null, config("sales", "forwards", forwards("a@x.com", "b@y.com", "c@z.com")))
+	if len(diags) == 0 || !strings.Contains(diags[0].Summary, "at most 2") {
+		t.Errorf("wanted an error about the destination limit, got %v", diags)
+	}
+}
@@ -0,0 +1,159 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceCatchAll() *schema.Resource {
+	return &schema.Resource{
+		Description: "ImprovMX catch-all resource. Forwards emails sent to any address of a domain without an alias of its own. ImprovMX creates a catch-all for every new domain, which this resource adopts. Do not combine with `alias` blocks on `improvmx_domain` or with `improvmx_domain_aliases` for the same domain, as both remove the catch-all.",
+
+		CreateContext: resourceCatchAllCreate,
+		ReadContext:   resourceCatchAllRead,
+		UpdateContext: resourceCatchAllUpdate,
+		DeleteContext: resourceCatchAllDelete,
+
+		CustomizeDiff: customizeForwardDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"forward":  forwardSchema,
+			"forwards": forwardsSchema,
+			"alias_id": {
+				Description: "Unique ID for the catch-all alias.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceCatchAllCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	if err := checkDestinationLimit(ctx, c, forwardFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// new domains come with a catch-all, so adopting it is expected
+	_, _, err := improvmx.EnsureAlias(ctx, c.Aliases(domain), catchAllFromResourceData(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(domain)
+
+	return append(resourceCatchAllRead(ctx, d, meta), duplicateAliasesWarning(ctx, c, d)...)
+}
+
+func resourceCatchAllRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+
+	aliases, err := c.Aliases(d.Id()).List(ctx)
+	if err != nil {
+		return removeIfNotFound(d, err)
+	}
+
+	for _, a := range *aliases {
+		if a.Alias != improvmx.CatchAll {
+			continue
+		}
+		d.Set("domain", d.Id())
+		setForward(d, a.Forward)
+		d.Set("alias_id", a.ID)
+		return nil
+	}
+
+	log.Printf("[WARN] catch-all of %s not found, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceCatchAllUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+
+	if err := checkDestinationLimit(ctx, c, forwardFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if _, err := c.Aliases(d.Id()).Update(ctx, catchAllFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return append(resourceCatchAllRead(ctx, d, meta), duplicateAliasesWarning(ctx, c, d)...)
+}
+
+func resourceCatchAllDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+
+	if err := ignoreNotFound(c.Aliases(d.Id()).Delete(ctx, catchAllFromResourceData(d))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func catchAllFromResourceData(d *schema.ResourceData) *improvmx.Alias {
+	return &improvmx.Alias{
+		Alias:   improvmx.CatchAll,
+		Forward: forwardFromResourceData(d),
+	}
+}
+
+// duplicateAliasesWarning warns when the catch-all forwards to the same
+// destinations as explicit aliases of the domain. Explicit aliases take
+// precedence over the catch-all, so these aliases could be removed without
+// changing where their emails go. It is only checked when the catch-all is
+// written, so that refreshes stay quiet, and failing to list the aliases
+// only skips the check.
+func duplicateAliasesWarning(ctx context.Context, c improvmx.Client, d *schema.ResourceData) diag.Diagnostics {
+	if d.Id() == "" {
+		return nil
+	}
+	aliases, err := c.Aliases(d.Id()).List(ctx)
+	if err != nil {
+		log.Printf("[DEBUG] not checking the catch-all of %s against its aliases: %s", d.Id(), err)
+		return nil
+	}
+	names := duplicateAliases(forwardFromResourceData(d), *aliases)
+	if len(names) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Aliases of %s forward to the same destinations as its catch-all", d.Id()),
+		Detail:   fmt.Sprintf("Explicit aliases take precedence over the catch-all, so removing %s would not change where their emails go.", strings.Join(names, ", ")),
+	}}
+}
+
+// duplicateAliases returns the sorted names of the aliases that forward to
+// exactly the destinations of the catch-all.
+func duplicateAliases(forward string, aliases []improvmx.Alias) []string {
+	dests := improvmx.Alias{Forward: forward}.Destinations()
+	var names []string
+	for _, a := range aliases {
+		if a.Alias != improvmx.CatchAll && slices.Equal(a.Destinations(), dests) {
+			names = append(names, a.Alias)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
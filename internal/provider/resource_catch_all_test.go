@@ -0,0 +1,92 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func testAccCatchAllConfig(forward string) string {
+	return fmt.Sprintf(`
+		resource "improvmx_domain" "test" {
+			domain = "%[1]s"
+		}
+
+		resource "improvmx_catch_all" "test" {
+			domain  = improvmx_domain.test.domain
+			forward = "%[2]s"
+		}
+	`, testDomain, forward)
+}
+
+func TestAccResourceCatchAll(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				// the default catch-all is adopted
+				Config: testAccCatchAllConfig("richard@piedpiper.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("improvmx_catch_all.test", "id", testDomain),
+					resource.TestCheckResourceAttr("improvmx_catch_all.test", "forward", "richard@piedpiper.com"),
+				),
+			},
+			{
+				Config: testAccCatchAllConfig("jared@piedpiper.com"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("improvmx_catch_all.test", "forward", "jared@piedpiper.com"),
+				),
+			},
+			{
+				ResourceName:      "improvmx_catch_all.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestDuplicateAliases(t *testing.T) {
+	aliases := []improvmx.Alias{
+		{Alias: "*", Forward: "richard@piedpiper.com,jared@piedpiper.com"},
+		{Alias: "hello", Forward: "Richard@piedpiper.com"},
+		{Alias: "billing", Forward: "jared@piedpiper.com, richard@piedpiper.com"},
+		{Alias: "support", Forward: "richard@piedpiper.com,monica@piedpiper.com"},
+	}
+
+	// only aliases with exactly the destinations of the catch-all duplicate it
+	names := duplicateAliases(aliases[0].Forward, aliases)
+	if fmt.Sprint(names) != "[billing]" {
+		t.Errorf("unexpected duplicate aliases: %v", names)
+	}
+
+	if names := duplicateAliases("gilfoyle@piedpiper.com", aliases); names != nil {
+		t.Errorf("expected no duplicate aliases, got %v", names)
+	}
+}
+
+func TestResourceCatchAllCreate_DuplicateAliases(t *testing.T) {
+	c := &fakeClient{aliases: map[string]string{
+		"*":       "richard@piedpiper.com",
+		"billing": "jared@piedpiper.com",
+	}}
+	d := schema.TestResourceDataRaw(t, resourceCatchAll().Schema, map[string]interface{}{
+		"domain":  "piedpiper.com",
+		"forward": "jared@piedpiper.com",
+	})
+
+	diags := resourceCatchAllCreate(context.Background(), d, c)
+	if diags.HasError() || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("wanted a warning about duplicate aliases, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail, "billing") {
+		t.Errorf("wanted warning to name the duplicate alias, got %q", diags[0].Detail)
+	}
+}
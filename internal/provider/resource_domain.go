@@ -2,10 +2,19 @@ package improvmx
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 
 	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/sync/errgroup"
 )
 
 var domainSchema = map[string]*schema.Schema{
@@ -62,7 +71,7 @@ var domainSchema = map[string]*schema.Schema{
 					Computed:    true,
 				},
 				"name": {
-					Description: "Relative name of the object affected by this record. Only applicable for CNAME records. Example: 'dkimprovmx1._domainkey'.",
+					Description: "Relative name of the object affected by this record. Empty for records on the domain itself. Example: 'dkimprovmx1._domainkey'. Use `improvmx_dns_records` for records ready to pass to a DNS provider.",
 					Type:        schema.TypeString,
 					Computed:    true,
 				},
@@ -77,40 +86,142 @@ var domainSchema = map[string]*schema.Schema{
 	"alias": {
 		Description: "List of domain aliases.",
 		Type:        schema.TypeSet,
-		Set:         hashSetValue("alias"),
+		Set:         hashAlias,
 		Optional:    true,
-		Elem: &schema.Resource{
-			Schema: map[string]*schema.Schema{
-				"alias": {
-					Description: "Alias to be used in front of your domain, e.g. “contact”, “info”, etc.",
-					Type:        schema.TypeString,
-					Required:    true,
-				},
-				"forward": {
-					Description: "Destination email or endpoint to forward emails to.",
-					Type:        schema.TypeString,
-					Required:    true,
-				},
-				"id": {
-					Description: "Unique ID for alias.",
-					Type:        schema.TypeInt,
-					Computed:    true,
-				},
-			},
+		Elem:        aliasElem,
+	},
+}
+
+var aliasElem = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"alias": {
+			Description: "Alias to be used in front of your domain, e.g. “contact”, “info”, etc.",
+			Type:        schema.TypeString,
+			Required:    true,
+		},
+		"forward": {
+			Description:      "Destination email or endpoint to forward emails to. Separate multiple destinations with commas. Exactly one of `forward` or `forwards` must be set.",
+			Type:             schema.TypeString,
+			Optional:         true,
+			DiffSuppressFunc: suppressEquivalentForward,
+		},
+		"forwards": {
+			Description: "Destination emails or endpoints to forward emails to. Destinations are compared without surrounding spaces and with the domains of emails in lower case. Exactly one of `forward` or `forwards` must be set.",
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Set:         hashDestination,
+		},
+		"id": {
+			Description: "Unique ID for alias.",
+			Type:        schema.TypeInt,
+			Computed:    true,
 		},
 	},
 }
 
+// hashAlias hashes an `alias` block by its name and destinations. Leaving
+// out the destinations would hide changes to where an alias forwards from the
+// diff, while normalizing them keeps equivalent forwards, whether set in
+// `forward` or `forwards`, from showing up.
+func hashAlias(v interface{}) int {
+	item := v.(map[string]interface{})
+	return hash(item["alias"].(string) + "=" + improvmx.JoinDestinations(improvmx.Alias{Forward: aliasForward(item)}.Destinations()))
+}
+
+// aliasForward returns the destinations of an `alias` block as a
+// comma-separated string, taken from `forward` if it is set and from
+// `forwards` otherwise.
+func aliasForward(item map[string]interface{}) string {
+	if forward, _ := item["forward"].(string); forward != "" {
+		return forward
+	}
+	return joinSetDestinations(item["forwards"])
+}
+
+// customizeAliasBlocksDiff checks that exactly one of `forward` or
+// `forwards` is set in each `alias` block. Blocks that are not known yet are
+// checked once they are.
+func customizeAliasBlocksDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return nil
+	}
+	blocks := config.GetAttr("alias")
+	if blocks.IsNull() || !blocks.IsKnown() {
+		return nil
+	}
+	for it := blocks.ElementIterator(); it.Next(); {
+		_, block := it.Element()
+		if !block.IsKnown() {
+			continue
+		}
+		forward, forwards := block.GetAttr("forward"), block.GetAttr("forwards")
+		if !forward.IsKnown() || !forwards.IsKnown() || forward.IsNull() != forwards.IsNull() {
+			continue
+		}
+		name := ""
+		if alias := block.GetAttr("alias"); alias.IsKnown() && !alias.IsNull() {
+			name = alias.AsString()
+		}
+		return fmt.Errorf("alias %q: exactly one of forward or forwards must be set", name)
+	}
+	return nil
+}
+
+// aliasConcurrency is the number of alias requests made in parallel when
+// updating the aliases of a domain.
+const aliasConcurrency = 8
+
+// Modes of managing the aliases of a domain.
+const (
+	aliasManagementAuthoritative = "authoritative"
+	aliasManagementAdditive      = "additive"
+)
+
 func resourceDomain() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"alias_management": {
+			Description:  "How the `alias` blocks are managed. With `authoritative`, aliases not declared in `alias` blocks are deleted. With `additive`, only aliases declared in `alias` blocks are created, updated and deleted, while aliases added elsewhere, e.g. in the ImprovMX dashboard, are left as they are. Defaults to `authoritative`.",
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      aliasManagementAuthoritative,
+			ValidateFunc: validation.StringInSlice([]string{aliasManagementAuthoritative, aliasManagementAdditive}, false),
+		},
+		"adopt_existing": {
+			Description: "Manage the domain if it has already been added to the account, instead of failing. Its settings are updated to match the configuration. Defaults to `false`, in which case existing domains have to be imported.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"report_unmanaged_aliases": {
+			Description: "Warn about aliases that are not declared in `alias` blocks when `alias_management` is `additive`.",
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+		},
+		"managed_aliases": {
+			Description: "Names of the aliases Terraform has created or adopted. When `alias_management` is `additive`, only these aliases are read into state and deleted.",
+			Type:        schema.TypeSet,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Computed:    true,
+		},
+	}
+	for k, v := range domainSchema {
+		s[k] = v
+	}
+
 	return &schema.Resource{
 		Description: "ImprovMX domain resource.",
-		Schema:      domainSchema,
+		Schema:      s,
 
 		CreateContext: resourceDomainCreate,
 		ReadContext:   resourceDomainRead,
 		UpdateContext: resourceDomainUpdate,
 		DeleteContext: resourceDomainDelete,
 
+		CustomizeDiff: customdiff.All(customizeAliasBlocksDiff, customizeDomainDiff),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -120,83 +231,308 @@ func resourceDomain() *schema.Resource {
 func resourceDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	c := meta.(improvmx.Client)
 
-	// add domain
+	if err := checkAliasesDestinationLimit(ctx, c, aliasesFromSet(d.Get("alias").(*schema.Set))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// add domain, adopting it if it has already been added to the account and
+	// adopting has been enabled
 	inputDomain := domainFromResourceData(d)
-	domain, err := c.AddDomain(ctx, inputDomain)
+	domain, original, result, err := addDomain(ctx, c.Domains(), inputDomain, d.Get("adopt_existing").(bool))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(domain.Domain)
+	if result != improvmx.EnsureCreated {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Adopted existing domain %s", domain.Domain),
+			Detail:   "The domain was already registered with ImprovMX and is now managed by Terraform.",
+		})
+	}
 
 	// domain email aliases
+	managed := map[string]bool{}
 	domain.Aliases = aliasesFromSet(d.Get("alias").(*schema.Set))
 	if domain.Aliases != nil {
-		// get aliases created by default when the domain is first created
-		defaultAliases, err := c.ListAliases(ctx, domain.Domain)
-		if err != nil {
-			return diag.FromErr(err)
+		changes := &aliasChanges{service: c.Aliases(domain.Domain), additive: additiveAliases(d)}
+		if err := changes.apply(ctx, *domain.Aliases); err != nil {
+			return append(diags, rollbackDomainCreate(ctx, d, c, result, original, changes, err))
+		}
+		for _, a := range *domain.Aliases {
+			managed[a.Alias] = true
+		}
+	}
+	setManagedAliases(d, managed)
+
+	return append(diags, resourceDomainRead(ctx, d, meta)...)
+}
+
+// addDomain adds domain to the account. If it already exists, it is adopted
+// when adopt is set, and an error pointing to `terraform import` is returned
+// otherwise. The settings of an adopted domain from before it was updated
+// are returned as original, so that a failed create can restore them.
+func addDomain(ctx context.Context, s improvmx.DomainService, domain *improvmx.Domain, adopt bool) (added, original *improvmx.Domain, result improvmx.EnsureResult, err error) {
+	if adopt {
+		original, err = s.Get(ctx, domain.Domain)
+		if err != nil && !errors.Is(err, improvmx.ErrNotFound) {
+			return nil, nil, "", err
 		}
+		added, result, err = improvmx.EnsureDomain(ctx, s, domain)
+		return added, original, result, err
+	}
 
-		// delete default alias(es) if the resource has defined its own resources
-		for _, a := range *defaultAliases {
-			if err = c.DeleteAlias(ctx, domain.Domain, &a); err != nil {
-				diags = append(diags, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  err.Error(),
-				})
+	added, err = s.Add(ctx, domain)
+	if err == nil {
+		return added, nil, improvmx.EnsureCreated, nil
+	}
+	if existing, getErr := s.Get(ctx, domain.Domain); getErr == nil && existing != nil {
+		return nil, nil, "", fmt.Errorf("domain %s has already been added to the account: import it with `terraform import`, or set `adopt_existing` to manage it", domain.Domain)
+	}
+	return nil, nil, "", err
+}
+
+// rollbackDomainCreate undoes a create whose aliases could not be applied, so
+// that it either fully succeeds or leaves nothing behind. A domain added by
+// the create is deleted, while the alias changes to an adopted domain are
+// reverted and its original settings restored. Should deleting an added
+// domain fail, it is kept in state so that the next apply replaces it.
+// Adopted domains are never kept, as replacing them would delete a domain
+// Terraform did not add; the next apply adopts them again instead.
+func rollbackDomainCreate(ctx context.Context, d *schema.ResourceData, c improvmx.Client, result improvmx.EnsureResult, original *improvmx.Domain, changes *aliasChanges, err error) diag.Diagnostic {
+	detail := changes.String()
+	if result == improvmx.EnsureCreated {
+		if rollbackErr := ignoreNotFound(c.Domains().Delete(ctx, &improvmx.Domain{Domain: d.Id()})); rollbackErr != nil {
+			detail += fmt.Sprintf("\n\nDeleting the domain again failed: %s\nThe domain is kept in state and will be replaced on the next apply.", rollbackErr)
+		} else {
+			detail += "\n\nThe domain has been deleted again."
+			d.SetId("")
+		}
+	} else {
+		if rollbackErr := changes.rollback(ctx); rollbackErr != nil {
+			detail += fmt.Sprintf("\n\nRestoring the aliases of the domain failed: %s\nThe domain will be adopted again on the next apply.", rollbackErr)
+		} else {
+			detail += "\n\nThe aliases of the domain have been restored."
+		}
+		if result == improvmx.EnsureUpdated && original != nil {
+			_, rollbackErr := c.Domains().Update(ctx, &improvmx.Domain{
+				Domain:            original.Domain,
+				NotificationEmail: original.NotificationEmail,
+				Webhook:           original.Webhook,
+				Whitelabel:        original.Whitelabel,
+			})
+			if rollbackErr != nil {
+				detail += fmt.Sprintf("\n\nRestoring the settings of the domain failed: %s\nThe domain will be adopted again on the next apply.", rollbackErr)
+			} else {
+				detail += "\n\nThe settings of the domain have been restored."
 			}
 		}
-		if diags.HasError() {
-			return diags
+		d.SetId("")
+	}
+
+	return diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Error configuring aliases of domain %s: %s", d.Get("domain").(string), err),
+		Detail:   detail,
+	}
+}
+
+// aliasChanges replaces the aliases of a domain one operation at a time,
+// recording which operations succeeded and failed, and how to undo them.
+type aliasChanges struct {
+	service   improvmx.AliasService
+	additive  bool
+	succeeded []string
+	failed    []string
+	undo      []func(context.Context) error
+}
+
+// apply brings the aliases of the domain in line with aliases. Existing
+// aliases of the same name as one of aliases are kept, and updated in place
+// if they forward somewhere else. When authoritative, the other existing
+// aliases of the domain, such as the catch-all ImprovMX creates for new
+// domains, are deleted first. When additive, they are left as they are.
+// Creating and updating is skipped if any of the deletes fail. The first
+// error is returned.
+func (c *aliasChanges) apply(ctx context.Context, aliases []improvmx.Alias) error {
+	existing, err := c.service.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	record := func(op string, err error) {
+		if err == nil {
+			c.succeeded = append(c.succeeded, op)
+			return
+		}
+		c.failed = append(c.failed, fmt.Sprintf("%s: %s", op, err))
+		if firstErr == nil {
+			firstErr = err
 		}
+	}
 
-		// add aliases after domain has been created and any default aliases have
-		// been deleted
-		for _, alias := range *domain.Aliases {
-			_, err = c.CreateAlias(ctx, domain.Domain, &alias)
-			if err != nil {
-				diags = append(diags, diag.Diagnostic{Severity: diag.Error, Summary: err.Error()})
-			}
+	names := map[string]bool{}
+	for _, a := range aliases {
+		names[a.Alias] = true
+	}
+	var current []improvmx.Alias
+	prev := map[string]improvmx.Alias{}
+	for _, a := range *existing {
+		if c.additive && !names[a.Alias] {
+			continue
 		}
-		if diags.HasError() {
-			return diags
+		current = append(current, a)
+		prev[a.Alias] = a
+	}
+	deletes, creates, updates := diffAliases(&current, &aliases)
+
+	for _, a := range deletes {
+		a := a
+		err := ignoreNotFound(c.service.Delete(ctx, &a))
+		record(fmt.Sprintf("delete alias %s", a.Alias), err)
+		if err == nil {
+			c.undo = append(c.undo, func(ctx context.Context) error {
+				_, err := c.service.Create(ctx, &improvmx.Alias{Alias: a.Alias, Forward: a.Forward})
+				return err
+			})
 		}
 	}
+	if firstErr != nil {
+		return firstErr
+	}
 
-	return resourceDomainRead(ctx, d, meta)
+	for _, a := range creates {
+		a := a
+		_, err := c.service.Create(ctx, &a)
+		record(fmt.Sprintf("create alias %s", a.Alias), err)
+		if err == nil {
+			c.undo = append(c.undo, func(ctx context.Context) error {
+				return ignoreNotFound(c.service.Delete(ctx, &a))
+			})
+		}
+	}
+	for _, a := range updates {
+		a, old := a, prev[a.Alias]
+		_, err := c.service.Update(ctx, &a)
+		record(fmt.Sprintf("update alias %s", a.Alias), err)
+		if err == nil {
+			c.undo = append(c.undo, func(ctx context.Context) error {
+				_, err := c.service.Update(ctx, &improvmx.Alias{Alias: old.Alias, Forward: old.Forward})
+				return err
+			})
+		}
+	}
+	return firstErr
+}
+
+// rollback undoes the successful operations in reverse order.
+func (c *aliasChanges) rollback(ctx context.Context) error {
+	var errs []error
+	for i := len(c.undo) - 1; i >= 0; i-- {
+		if err := c.undo[i](ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String lists the operations that succeeded and failed.
+func (c *aliasChanges) String() string {
+	list := func(ops []string) string {
+		if len(ops) == 0 {
+			return "\n  (none)"
+		}
+		return "\n  - " + strings.Join(ops, "\n  - ")
+	}
+	return "Succeeded:" + list(c.succeeded) + "\n\nFailed:" + list(c.failed)
 }
 
 func resourceDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(improvmx.Client)
 
-	inputDomain := domainFromResourceData(d)
-	domain, err := c.UpdateDomain(ctx, inputDomain)
-	if err != nil {
-		return diag.FromErr(err)
+	if d.HasChanges("notification_email", "webhook", "whitelabel") {
+		if _, err := c.Domains().Update(ctx, domainFromResourceData(d)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	domain.Aliases = aliasesFromSet(d.Get("alias").(*schema.Set))
-	if domain.Aliases != nil || d.HasChange("alias") {
+	if d.HasChange("alias") {
 		old, new := getSetChange(d, "alias")
-		// create if alias in new, but not in old
-		for _, a := range *aliasesFromSet(new.Difference(old)) {
-			_, err = c.CreateAlias(ctx, domain.Domain, &a)
-			if err != nil {
-				return diag.FromErr(err)
+		deletes, creates, updates := diffAliases(aliasesFromSet(old), aliasesFromSet(new))
+		aliases := c.Aliases(d.Id())
+		additive := additiveAliases(d)
+
+		if err := checkAliasesDestinationLimit(ctx, c, aliasesFromSet(new)); err != nil {
+			return diag.FromErr(err)
+		}
+
+		oldManaged, _ := d.GetChange("managed_aliases")
+		managed := managedAliases(oldManaged.(*schema.Set), old)
+		var mu sync.Mutex
+		track := func(name string, ok bool) {
+			mu.Lock()
+			defer mu.Unlock()
+			if ok {
+				managed[name] = true
+			} else {
+				delete(managed, name)
 			}
 		}
-		// delete if alias in old, but not in new
-		for _, a := range *aliasesFromSet(old.Difference(new)) {
-			if err = c.DeleteAlias(ctx, domain.Domain, &a); err != nil {
-				return diag.FromErr(err)
+
+		// delete first, so that aliases can be created in place of the ones
+		// they replace
+		var ops []func(context.Context) error
+		for _, a := range deletes {
+			a := a
+			if additive && !managed[a.Alias] {
+				continue
 			}
+			ops = append(ops, func(ctx context.Context) error {
+				// aliases deleted outside of Terraform need no deleting
+				err := ignoreNotFound(aliases.Delete(ctx, &a))
+				if err == nil {
+					track(a.Alias, false)
+				}
+				return err
+			})
 		}
-		// update if alias in both old and new
-		for _, a := range *aliasesFromSet(new.Intersection(old)) {
-			_, err = c.UpdateAlias(ctx, domain.Domain, &a)
-			if err != nil {
-				return diag.FromErr(err)
+		err := runParallel(ctx, ops...)
+
+		if err == nil {
+			ops = nil
+			for _, a := range creates {
+				a := a
+				ops = append(ops, func(ctx context.Context) error {
+					var err error
+					if additive {
+						// take over aliases that were added outside of Terraform
+						_, _, err = improvmx.EnsureAlias(ctx, aliases, &a)
+					} else {
+						_, err = aliases.Create(ctx, &a)
+					}
+					if err == nil {
+						track(a.Alias, true)
+					}
+					return err
+				})
+			}
+			for _, a := range updates {
+				a := a
+				ops = append(ops, func(ctx context.Context) error {
+					_, err := aliases.Update(ctx, &a)
+					if err == nil {
+						track(a.Alias, true)
+					}
+					return err
+				})
 			}
+			err = runParallel(ctx, ops...)
+		}
+
+		setManagedAliases(d, managed)
+		if err != nil {
+			return diag.FromErr(err)
 		}
 	}
 
@@ -206,36 +542,121 @@ func resourceDomainUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 func resourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(improvmx.Client)
 
-	domain, err := c.GetDomain(ctx, d.Id())
+	domain, err := c.Domains().Get(ctx, d.Id())
 	if err != nil {
-		return diag.FromErr(err)
+		return removeIfNotFound(d, err)
 	}
 
 	// check domain
-	check, err := c.CheckDomain(ctx, domain.Domain)
+	check, err := c.Domains().Check(ctx, domain.Domain)
 	if err != nil {
-		return diag.FromErr(err)
+		return removeIfNotFound(d, err)
 	}
 
 	var dns []map[string]interface{}
 	dns = append(dns, makeDNSRecord(check.Mx.Expected, "MX", "")...)
 	dns = append(dns, makeDNSRecord(check.Spf.Expected, "TXT", "")...)
-	dns = append(dns, makeDNSRecord(check.Dmarc.Expected, "TXT", "")...)
+	dns = append(dns, makeDNSRecord(check.Dmarc.Expected, "TXT", "_dmarc")...)
 	dns = append(dns, makeDNSRecord(check.Dkim1.Expected, "CNAME", "dkimprovmx1._domainkey")...)
 	dns = append(dns, makeDNSRecord(check.Dkim2.Expected, "CNAME", "dkimprovmx2._domainkey")...)
 	d.Set("dns", dns)
 
 	inputAliases := d.Get("alias").(*schema.Set)
+
+	var diags diag.Diagnostics
+	if additiveAliases(d) && domain.Aliases != nil {
+		names := managedAliases(d.Get("managed_aliases").(*schema.Set), inputAliases)
+		managed, unmanaged := partitionAliases(*domain.Aliases, names)
+		domain.Aliases = &managed
+		if d.Get("report_unmanaged_aliases") == true {
+			diags = unmanagedAliasDiagnostics(domain.Domain, unmanaged)
+		}
+	}
+
 	if inputAliases.Len() == 0 {
 		domain.Aliases = nil
 	}
 
-	return resourceDataFromDomain(domain, d)
+	return append(diags, resourceDataFromDomain(domain, d)...)
+}
+
+// additiveAliases reports whether only the aliases declared in `alias` blocks
+// are managed. The domain data source shares the read of the resource, but
+// has no `alias_management`.
+func additiveAliases(d *schema.ResourceData) bool {
+	return d.Get("alias_management") == aliasManagementAdditive
+}
+
+// customizeDomainDiff marks `managed_aliases` as computed when the aliases
+// change, as they are recorded once the aliases have been applied.
+func customizeDomainDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && d.HasChange("alias") {
+		return d.SetNewComputed("managed_aliases")
+	}
+	return nil
+}
+
+// managedAliases returns the names of the aliases Terraform has created or
+// adopted, as recorded in `managed_aliases`. States written before they were
+// recorded fall back to the names in the set of `alias` blocks.
+func managedAliases(managed, aliases *schema.Set) map[string]bool {
+	names := map[string]bool{}
+	for _, v := range managed.List() {
+		names[v.(string)] = true
+	}
+	if len(names) == 0 {
+		for _, v := range aliases.List() {
+			names[v.(map[string]interface{})["alias"].(string)] = true
+		}
+	}
+	return names
+}
+
+func setManagedAliases(d *schema.ResourceData, managed map[string]bool) {
+	var names []interface{}
+	for name, ok := range managed {
+		if ok {
+			names = append(names, name)
+		}
+	}
+	d.Set("managed_aliases", schema.NewSet(schema.HashString, names))
+}
+
+// partitionAliases splits aliases into those Terraform manages, and those
+// added outside of Terraform. Only managed aliases are read into state, so
+// only aliases Terraform has created or adopted are ever deleted.
+func partitionAliases(aliases []improvmx.Alias, names map[string]bool) (managed, unmanaged []improvmx.Alias) {
+	managed = []improvmx.Alias{}
+	for _, a := range aliases {
+		if names[a.Alias] {
+			managed = append(managed, a)
+		} else {
+			unmanaged = append(unmanaged, a)
+		}
+	}
+	return managed, unmanaged
+}
+
+func unmanagedAliasDiagnostics(domain string, unmanaged []improvmx.Alias) diag.Diagnostics {
+	if len(unmanaged) == 0 {
+		return nil
+	}
+	names := make([]string, len(unmanaged))
+	for i, a := range unmanaged {
+		names[i] = a.Alias
+	}
+	sort.Strings(names)
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Domain %s has unmanaged aliases", domain),
+		Detail:   fmt.Sprintf("These aliases are not declared in alias blocks and are left as they are: %s", strings.Join(names, ", ")),
+	}}
 }
 
 func resourceDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(improvmx.Client)
-	err := c.DeleteDomain(ctx, &improvmx.Domain{Domain: d.Id()})
+	err := ignoreNotFound(c.Domains().Delete(ctx, &improvmx.Domain{Domain: d.Id()}))
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -258,16 +679,7 @@ func resourceDataFromDomain(domain *improvmx.Domain, d *schema.ResourceData) dia
 		return nil
 	}
 
-	aliasList := make([]interface{}, len(*domain.Aliases))
-	for i, a := range *domain.Aliases {
-		aliasList[i] = map[string]interface{}{
-			"alias":   a.Alias,
-			"forward": a.Forward,
-			"id":      a.ID,
-		}
-	}
-	aliases := schema.NewSet(hashSetValue("alias"), aliasList)
-	d.Set("alias", aliases)
+	d.Set("alias", aliasSet(*domain.Aliases, d.Get("alias").(*schema.Set)))
 
 	return nil
 }
@@ -281,12 +693,61 @@ func aliasesFromSet(s *schema.Set) *[]improvmx.Alias {
 		item := a.(map[string]interface{})
 		aliases[i] = improvmx.Alias{
 			Alias:   item["alias"].(string),
-			Forward: item["forward"].(string),
+			Forward: aliasForward(item),
 		}
 	}
 	return &aliases
 }
 
+// diffAliases compares aliases by name, returning the aliases to delete,
+// create and update to get from old to new. Aliases whose forward is
+// unchanged are left out.
+func diffAliases(old, new *[]improvmx.Alias) (deletes, creates, updates []improvmx.Alias) {
+	byName := func(aliases *[]improvmx.Alias) map[string]improvmx.Alias {
+		m := map[string]improvmx.Alias{}
+		if aliases != nil {
+			for _, a := range *aliases {
+				m[a.Alias] = a
+			}
+		}
+		return m
+	}
+	oldAliases, newAliases := byName(old), byName(new)
+
+	for name, a := range oldAliases {
+		if _, ok := newAliases[name]; !ok {
+			deletes = append(deletes, a)
+		}
+	}
+	for name, a := range newAliases {
+		prev, ok := oldAliases[name]
+		switch {
+		case !ok:
+			creates = append(creates, a)
+		case !slices.Equal(prev.Destinations(), a.Destinations()):
+			updates = append(updates, a)
+		}
+	}
+
+	for _, aliases := range [][]improvmx.Alias{deletes, creates, updates} {
+		sort.Slice(aliases, func(i, j int) bool { return aliases[i].Alias < aliases[j].Alias })
+	}
+	return deletes, creates, updates
+}
+
+// runParallel runs ops with at most aliasConcurrency of them in flight,
+// returning the first error. Once an op fails, the context of the remaining
+// ones is cancelled.
+func runParallel(ctx context.Context, ops ...func(context.Context) error) error {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(aliasConcurrency)
+	for _, op := range ops {
+		op := op
+		g.Go(func() error { return op(ctx) })
+	}
+	return g.Wait()
+}
+
 func domainFromResourceData(d *schema.ResourceData) *improvmx.Domain {
 	return &improvmx.Domain{
 		Domain:            d.Get("domain").(string),
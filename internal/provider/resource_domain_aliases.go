@@ -0,0 +1,211 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// bulkAliasChunkSize is the maximum number of aliases sent in a single bulk
+// request.
+const bulkAliasChunkSize = 100
+
+func resourceDomainAliases() *schema.Resource {
+	return &schema.Resource{
+		Description: "Authoritative set of a domain's aliases. Any alias not defined by this resource, including the aliases ImprovMX creates by default, is removed on apply and reported as drift on plan. Do not combine with `alias` blocks on `improvmx_domain` for the same domain.",
+
+		CreateContext: resourceDomainAliasesCreate,
+		ReadContext:   resourceDomainAliasesRead,
+		UpdateContext: resourceDomainAliasesUpdate,
+		DeleteContext: resourceDomainAliasesDelete,
+
+		CustomizeDiff: customizeAliasBlocksDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"alias": {
+				Description: "Complete set of the domain's aliases.",
+				Type:        schema.TypeSet,
+				Set:         hashAlias,
+				Optional:    true,
+				Elem:        aliasElem,
+			},
+		},
+	}
+}
+
+func resourceDomainAliasesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	aliases := aliasesFromSet(d.Get("alias").(*schema.Set))
+	if err := checkAliasesDestinationLimit(ctx, c, aliases); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := syncAliases(ctx, c.Aliases(domain), aliases); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(domain)
+
+	return resourceDomainAliasesRead(ctx, d, meta)
+}
+
+func resourceDomainAliasesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+
+	aliases, err := c.Aliases(d.Id()).List(ctx)
+	if err != nil {
+		return removeIfNotFound(d, err)
+	}
+
+	d.Set("domain", d.Id())
+	d.Set("alias", aliasSet(*aliases, d.Get("alias").(*schema.Set)))
+	return nil
+}
+
+func resourceDomainAliasesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+
+	aliases := aliasesFromSet(d.Get("alias").(*schema.Set))
+	if err := checkAliasesDestinationLimit(ctx, c, aliases); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := syncAliases(ctx, c.Aliases(d.Id()), aliases); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDomainAliasesRead(ctx, d, meta)
+}
+
+func resourceDomainAliasesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+
+	aliases := aliasesFromSet(d.Get("alias").(*schema.Set))
+	if aliases == nil {
+		return nil
+	}
+	if err := ignoreNotFound(bulkAliases(ctx, c.Aliases(d.Id()), improvmx.BulkAliasDelete, *aliases)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// syncAliases makes the aliases of a domain match desired exactly. Aliases
+// are deleted, updated and then added in bulk, so that an alias removed in
+// the same change never conflicts with one being added.
+func syncAliases(ctx context.Context, s improvmx.AliasService, desired *[]improvmx.Alias) error {
+	current, err := s.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]improvmx.Alias, len(*current))
+	for _, a := range *current {
+		existing[a.Alias] = a
+	}
+	wanted := map[string]improvmx.Alias{}
+	if desired != nil {
+		for _, a := range *desired {
+			wanted[a.Alias] = a
+		}
+	}
+
+	var remove, update, add []improvmx.Alias
+	for name, a := range existing {
+		if _, ok := wanted[name]; !ok {
+			remove = append(remove, improvmx.Alias{Alias: a.Alias})
+		}
+	}
+	for name, a := range wanted {
+		e, ok := existing[name]
+		switch {
+		case !ok:
+			add = append(add, a)
+		case !slices.Equal(e.Destinations(), a.Destinations()):
+			update = append(update, a)
+		}
+	}
+
+	if err = bulkAliases(ctx, s, improvmx.BulkAliasDelete, remove); err != nil {
+		return err
+	}
+	if err = bulkAliases(ctx, s, improvmx.BulkAliasUpdate, update); err != nil {
+		return err
+	}
+	return bulkAliases(ctx, s, improvmx.BulkAliasAdd, add)
+}
+
+// bulkAliases sends aliases to the bulk endpoint in chunks, returning an
+// error that names every alias the API failed to process.
+func bulkAliases(ctx context.Context, s improvmx.AliasService, behavior improvmx.BulkAliasBehavior, aliases []improvmx.Alias) error {
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Alias < aliases[j].Alias })
+
+	var failed []string
+	for start := 0; start < len(aliases); start += bulkAliasChunkSize {
+		end := start + bulkAliasChunkSize
+		if end > len(aliases) {
+			end = len(aliases)
+		}
+		result, err := s.Bulk(ctx, behavior, aliases[start:end])
+		if err != nil {
+			return err
+		}
+		for _, a := range result.Failed {
+			failed = append(failed, a.Alias)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to %s aliases: %s", behavior, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// aliasSet builds a set of `alias` blocks from aliases. The destinations of
+// an alias are stored in `forwards` if its block in prior uses `forwards`,
+// and in `forward` otherwise, so that how they are configured is preserved.
+func aliasSet(aliases []improvmx.Alias, prior *schema.Set) *schema.Set {
+	useForwards := map[string]bool{}
+	if prior != nil {
+		for _, v := range prior.List() {
+			item := v.(map[string]interface{})
+			if forwards, ok := item["forwards"].(*schema.Set); ok && forwards.Len() > 0 {
+				useForwards[item["alias"].(string)] = true
+			}
+		}
+	}
+
+	aliasList := make([]interface{}, len(aliases))
+	for i, a := range aliases {
+		item := map[string]interface{}{
+			"alias":   a.Alias,
+			"forward": a.Forward,
+			"id":      a.ID,
+		}
+		if useForwards[a.Alias] {
+			var dests []interface{}
+			for _, dest := range a.Destinations() {
+				dests = append(dests, dest)
+			}
+			item["forward"] = ""
+			item["forwards"] = schema.NewSet(hashDestination, dests)
+		}
+		aliasList[i] = item
+	}
+	return schema.NewSet(hashAlias, aliasList)
+}
@@ -0,0 +1,223 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccResourceDomainAliases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "improvmx_domain" "test" {
+						domain = "%s"
+					}
+
+					resource "improvmx_domain_aliases" "test" {
+						domain = improvmx_domain.test.domain
+
+						alias {
+							alias   = "hello"
+							forward = "hello@piedpiper.com"
+						}
+
+						alias {
+							alias   = "contact"
+							forward = "contact@piedpiper.com"
+						}
+					}
+				`, testDomain),
+				// the default catch-all alias is removed
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("improvmx_domain_aliases.test", "alias.#", "2"),
+					testAccCheckDomainAliasCount("improvmx_domain_aliases.test", 2),
+				),
+			},
+			{
+				// aliases added outside of Terraform show up as drift
+				PreConfig: func() {
+					improvmxClient.Aliases(testDomain).Create(context.Background(), &improvmx.Alias{
+						Alias:   "marketing",
+						Forward: "marketing@piedpiper.com",
+					})
+				},
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				ResourceName:      "improvmx_domain_aliases.test",
+				ImportState:       true,
+				ImportStateId:     testDomain,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestSyncAliases(t *testing.T) {
+	c := &fakeClient{aliases: map[string]string{
+		"*":       "owner@piedpiper.com",
+		"hello":   "hello@piedpiper.com",
+		"contact": "contact@piedpiper.com",
+		"team":    "dinesh@piedpiper.com,gilfoyle@piedpiper.com",
+	}}
+
+	// team forwards to the same destinations, written differently
+	desired := []improvmx.Alias{
+		{Alias: "hello", Forward: "hello@piedpiper.com"},
+		{Alias: "contact", Forward: "richard@piedpiper.com"},
+		{Alias: "billing", Forward: "billing@piedpiper.com"},
+		{Alias: "team", Forward: "gilfoyle@PiedPiper.com, dinesh@piedpiper.com"},
+	}
+	if err := syncAliases(context.Background(), c.Aliases("piedpiper.com"), &desired); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"delete *", "update contact", "add billing"}
+	if !cmp.Equal(c.bulk, expected) {
+		t.Errorf("unexpected bulk requests: %s", cmp.Diff(expected, c.bulk))
+	}
+
+	var names []string
+	for name := range c.aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if !cmp.Equal(names, []string{"billing", "contact", "hello", "team"}) {
+		t.Errorf("unexpected aliases after sync: %v", names)
+	}
+}
+
+func TestResourceDomainAliases_Forwards(t *testing.T) {
+	ctx := context.Background()
+	c := &fakeClient{aliases: map[string]string{}, limits: &improvmx.AccountLimit{Destinations: 2}}
+
+	p := New("dev")()
+	p.ConfigureContextFunc = func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return c, nil
+	}
+	server := schema.NewGRPCProviderServer(p)
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	resourceType := schemaResp.ResourceSchemas["improvmx_domain_aliases"].ValueType().(tftypes.Object)
+	setType := resourceType.AttributeTypes["alias"].(tftypes.Set)
+	blockType := setType.ElementType.(tftypes.Object)
+
+	providerConfig := testDynamicValue(t, providerType, map[string]tftypes.Value{
+		"api_key": tftypes.NewValue(tftypes.String, "test"),
+	})
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: &providerConfig})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNoDiagnostics(t, configureResp.Diagnostics)
+
+	stringSet := func(values ...string) tftypes.Value {
+		if values == nil {
+			return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil)
+		}
+		var elems []tftypes.Value
+		for _, v := range values {
+			elems = append(elems, tftypes.NewValue(tftypes.String, v))
+		}
+		return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elems)
+	}
+	block := func(forward, forwards tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(blockType, map[string]tftypes.Value{
+			"alias":    tftypes.NewValue(tftypes.String, "team"),
+			"forward":  forward,
+			"forwards": forwards,
+			"id":       tftypes.NewValue(tftypes.Number, nil),
+		})
+	}
+	noForward := tftypes.NewValue(tftypes.String, nil)
+
+	// exactly one of forward or forwards is checked at plan time
+	cases := map[string]struct {
+		block tftypes.Value
+		err   bool
+	}{
+		"forward":         {block(tftypes.NewValue(tftypes.String, "dinesh@piedpiper.com"), stringSet()), false},
+		"forwards":        {block(noForward, stringSet("dinesh@piedpiper.com")), false},
+		"unknown forward": {block(tftypes.NewValue(tftypes.String, tftypes.UnknownValue), stringSet()), false},
+		"both":            {block(tftypes.NewValue(tftypes.String, "dinesh@piedpiper.com"), stringSet("dinesh@piedpiper.com")), true},
+		"neither":         {block(noForward, stringSet()), true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := testDynamicValue(t, resourceType, map[string]tftypes.Value{
+				"domain": tftypes.NewValue(tftypes.String, "piedpiper.com"),
+				"alias":  tftypes.NewValue(setType, []tftypes.Value{tc.block}),
+			})
+			prior, _ := tfprotov5.NewDynamicValue(resourceType, tftypes.NewValue(resourceType, nil))
+			resp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+				TypeName:         "improvmx_domain_aliases",
+				PriorState:       &prior,
+				ProposedNewState: &config,
+				Config:           &config,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(resp.Diagnostics) > 0; got != tc.err {
+				t.Errorf("wanted error: %t, got %v", tc.err, resp.Diagnostics)
+			}
+		})
+	}
+
+	// destinations in forwards are normalized, and only compared by value
+	r := resourceDomainAliases()
+	config := func(forwards ...interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"domain": "piedpiper.com",
+			"alias": []interface{}{
+				map[string]interface{}{"alias": "team", "forwards": forwards},
+			},
+		})
+	}
+	diff, err := r.Diff(ctx, nil, config("gilfoyle@PiedPiper.com", " dinesh@piedpiper.com"), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, diags := r.Apply(ctx, nil, diff, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if forward := c.aliases["team"]; forward != "dinesh@piedpiper.com,gilfoyle@piedpiper.com" {
+		t.Errorf("unexpected forward: %q", forward)
+	}
+	if n := state.Attributes["alias.#"]; n != "1" {
+		t.Fatalf("wanted 1 alias in state, got %v", state.Attributes)
+	}
+	if diff, err := r.Diff(ctx, state, config("dinesh@piedpiper.com", "gilfoyle@piedpiper.com"), c); err != nil || diff != nil {
+		t.Errorf("wanted no changes for equivalent forwards, got %v, %v", diff, err)
+	}
+
+	// the destinations of every alias are checked against the account
+	diff, err = r.Diff(ctx, state, config("dinesh@piedpiper.com", "gilfoyle@piedpiper.com", "richard@piedpiper.com"), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, diags := r.Apply(ctx, state, diff, c); !diags.HasError() || !strings.Contains(diags[0].Summary, "at most 2") {
+		t.Errorf("wanted an error about the destination limit, got %v", diags)
+	}
+}
@@ -2,11 +2,19 @@ package improvmx
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -22,7 +30,7 @@ func TestAccResourceDomain(t *testing.T) {
 					continue
 				}
 
-				domain, err := improvmxClient.GetDomain(context.Background(), rs.Primary.ID)
+				domain, err := improvmxClient.Domains().Get(context.Background(), rs.Primary.ID)
 				if domain != nil && err == nil {
 					t.Fatalf("domain '%s' still available after destroy", domain.Domain)
 				}
@@ -82,7 +90,7 @@ func testAccCheckDomainAliasCount(resourceName string, expected int) resource.Te
 			return fmt.Errorf("domain not set on resource")
 		}
 
-		aliases, err := improvmxClient.ListAliases(context.Background(), rs.Primary.ID)
+		aliases, err := improvmxClient.Aliases(rs.Primary.ID).List(context.Background())
 		if err != nil {
 			return err
 		}
@@ -96,3 +104,326 @@ func testAccCheckDomainAliasCount(resourceName string, expected int) resource.Te
 		return nil
 	}
 }
+
+func TestResourceDomainCreate_Rollback(t *testing.T) {
+	existing := map[string]string{"*": "owner@piedpiper.com", "hello": "hello@piedpiper.com"}
+	cases := map[string]struct {
+		exists  bool
+		aliases map[string]string
+		webhook string
+	}{
+		// a domain added by the create is deleted again
+		"created": {false, map[string]string{}, "https://hooks.piedpiper.com/v2"},
+		// the aliases and settings of an adopted domain are restored
+		"adopted": {true, existing, "https://hooks.piedpiper.com"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &fakeClient{
+				exists:      tc.exists,
+				settings:    improvmx.Domain{Webhook: "https://hooks.piedpiper.com"},
+				aliases:     map[string]string{},
+				failAliases: map[string]bool{"contact": true},
+			}
+			for k, v := range existing {
+				c.aliases[k] = v
+			}
+			d := schema.TestResourceDataRaw(t, resourceDomain().Schema, map[string]interface{}{
+				"domain":         "piedpiper.com",
+				"webhook":        "https://hooks.piedpiper.com/v2",
+				"adopt_existing": tc.exists,
+				"alias": []interface{}{
+					map[string]interface{}{"alias": "hello", "forward": "hello@piedpiper.com"},
+					map[string]interface{}{"alias": "contact", "forward": "contact@piedpiper.com"},
+				},
+			})
+
+			diags := resourceDomainCreate(context.Background(), d, c)
+			if !diags.HasError() {
+				t.Fatal("expected an error")
+			}
+			if d.Id() != "" {
+				t.Errorf("wanted domain removed from state, got ID %q", d.Id())
+			}
+			if c.exists != tc.exists {
+				t.Errorf("wanted domain to exist: %t, got %t", tc.exists, c.exists)
+			}
+			if !cmp.Equal(c.aliases, tc.aliases) {
+				t.Errorf("unexpected aliases after rollback: %s", cmp.Diff(tc.aliases, c.aliases))
+			}
+			if c.settings.Webhook != tc.webhook {
+				t.Errorf("wanted webhook %q after rollback, got %q", tc.webhook, c.settings.Webhook)
+			}
+			// aliases that are already as configured are kept, with their IDs
+			if slices.Contains(c.deleted, "hello") {
+				t.Errorf("wanted unchanged alias kept, got deletes %v", c.deleted)
+			}
+
+			detail := diags[len(diags)-1].Detail
+			for _, op := range []string{"delete alias *", "create alias contact: alias rejected"} {
+				if !strings.Contains(detail, op) {
+					t.Errorf("wanted %q in error detail, got:\n%s", op, detail)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceDomain_AdditiveAliases(t *testing.T) {
+	c := &fakeClient{aliases: map[string]string{}}
+	d := schema.TestResourceDataRaw(t, resourceDomain().Schema, map[string]interface{}{
+		"domain":                   "piedpiper.com",
+		"alias_management":         "additive",
+		"report_unmanaged_aliases": true,
+		"alias": []interface{}{
+			map[string]interface{}{"alias": "hello", "forward": "hello@piedpiper.com"},
+		},
+	})
+
+	// the catch-all of the new domain is left in place, while an alias of
+	// the same name is adopted and updated in place
+	c.aliases["*"] = "owner@piedpiper.com"
+	c.aliases["hello"] = "richard@piedpiper.com"
+	if diags := resourceDomainCreate(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("create: unexpected error: %v", diags)
+	}
+	expected := map[string]string{"*": "owner@piedpiper.com", "hello": "hello@piedpiper.com"}
+	if !cmp.Equal(c.aliases, expected) {
+		t.Errorf("unexpected aliases after create: %s", cmp.Diff(expected, c.aliases))
+	}
+	if len(c.deleted) != 0 {
+		t.Errorf("wanted no aliases deleted, got %v", c.deleted)
+	}
+	if managed := d.Get("managed_aliases").(*schema.Set).List(); !cmp.Equal(managed, []interface{}{"hello"}) {
+		t.Errorf("wanted hello recorded as managed, got %v", managed)
+	}
+
+	// aliases added outside of Terraform are kept out of state and reported
+	c.aliases["promo"] = "marketing@piedpiper.com"
+	diags := resourceDomainRead(context.Background(), d, c)
+	if diags.HasError() {
+		t.Fatalf("read: unexpected error: %v", diags)
+	}
+	if n := d.Get("alias").(*schema.Set).Len(); n != 1 {
+		t.Errorf("wanted 1 alias in state, got %d", n)
+	}
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, "*, promo") {
+		t.Errorf("wanted a warning about unmanaged aliases, got %v", diags)
+	}
+
+	// declaring an alias does not make it managed until it has been adopted
+	d.Set("alias", aliasSet([]improvmx.Alias{
+		{Alias: "hello", Forward: "hello@piedpiper.com"},
+		{Alias: "promo", Forward: "marketing@piedpiper.com"},
+	}, nil))
+	if diags := resourceDomainRead(context.Background(), d, c); diags.HasError() {
+		t.Fatalf("read: unexpected error: %v", diags)
+	}
+	if n := d.Get("alias").(*schema.Set).Len(); n != 1 {
+		t.Errorf("wanted only the managed alias in state, got %d", n)
+	}
+}
+
+func TestResourceDomainCreate_Existing(t *testing.T) {
+	for _, adopt := range []bool{false, true} {
+		c := &fakeClient{exists: true, aliases: map[string]string{}}
+		d := schema.TestResourceDataRaw(t, resourceDomain().Schema, map[string]interface{}{
+			"domain":         "piedpiper.com",
+			"adopt_existing": adopt,
+		})
+
+		diags := resourceDomainCreate(context.Background(), d, c)
+		if adopt {
+			if diags.HasError() || d.Id() != "piedpiper.com" {
+				t.Errorf("adopt: wanted domain adopted, got ID %q and %v", d.Id(), diags)
+			}
+			continue
+		}
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, "terraform import") {
+			t.Errorf("wanted an error suggesting import, got %v", diags)
+		}
+		if d.Id() != "" {
+			t.Errorf("wanted domain not to be added to state, got ID %q", d.Id())
+		}
+	}
+}
+
+// testApplyDomain plans and applies raw against state, as Terraform does.
+func testApplyDomain(t *testing.T, c improvmx.Client, state *terraform.InstanceState, raw map[string]interface{}) *terraform.InstanceState {
+	t.Helper()
+	ctx := context.Background()
+	r := resourceDomain()
+	diff, err := r.Diff(ctx, state, terraform.NewResourceConfigRaw(raw), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state, diags := r.Apply(ctx, state, diff, c)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	return state
+}
+
+func TestResourceDomainUpdate(t *testing.T) {
+	c := &fakeClient{aliases: map[string]string{}}
+	apply := func(state *terraform.InstanceState, raw map[string]interface{}) *terraform.InstanceState {
+		t.Helper()
+		return testApplyDomain(t, c, state, raw)
+	}
+	config := func(webhook, forward string) map[string]interface{} {
+		return map[string]interface{}{
+			"domain":  "piedpiper.com",
+			"webhook": webhook,
+			"alias": []interface{}{
+				map[string]interface{}{"alias": "hello", "forward": forward},
+			},
+		}
+	}
+
+	state := apply(nil, config("https://hooks.piedpiper.com", "richard@piedpiper.com"))
+	c.updates = 0
+
+	// changing an alias leaves the domain settings alone
+	state = apply(state, config("https://hooks.piedpiper.com", "gilfoyle@piedpiper.com"))
+	if c.updates != 0 {
+		t.Errorf("wanted no domain updates when only aliases change, got %d", c.updates)
+	}
+	if c.aliases["hello"] != "gilfoyle@piedpiper.com" {
+		t.Errorf("wanted alias updated, got %v", c.aliases)
+	}
+
+	apply(state, config("https://hooks.piedpiper.com/v2", "gilfoyle@piedpiper.com"))
+	if c.updates != 1 || c.settings.Webhook != "https://hooks.piedpiper.com/v2" {
+		t.Errorf("wanted webhook updated once, got %d updates and %q", c.updates, c.settings.Webhook)
+	}
+}
+
+func TestResourceDomainUpdate_ManagedAliases(t *testing.T) {
+	c := &fakeClient{aliases: map[string]string{"promo": "marketing@piedpiper.com"}}
+	config := func(aliases ...string) map[string]interface{} {
+		var blocks []interface{}
+		for _, a := range aliases {
+			blocks = append(blocks, map[string]interface{}{"alias": a, "forward": a + "@piedpiper.com"})
+		}
+		return map[string]interface{}{
+			"domain":           "piedpiper.com",
+			"alias_management": "additive",
+			"alias":            blocks,
+		}
+	}
+
+	state := testApplyDomain(t, c, nil, config("hello", "support"))
+	if state.Attributes["managed_aliases.#"] != "2" {
+		t.Errorf("wanted 2 managed aliases, got %v", state.Attributes)
+	}
+
+	// only the alias Terraform created is deleted, and it is no longer
+	// recorded as managed
+	state = testApplyDomain(t, c, state, config("hello"))
+	if !cmp.Equal(c.deleted, []string{"support"}) {
+		t.Errorf("wanted only support deleted, got %v", c.deleted)
+	}
+	if state.Attributes["managed_aliases.#"] != "1" || state.Attributes["alias.#"] != "1" {
+		t.Errorf("wanted 1 managed alias in state, got %v", state.Attributes)
+	}
+	if _, ok := c.aliases["promo"]; !ok {
+		t.Errorf("wanted unmanaged alias kept, got %v", c.aliases)
+	}
+}
+
+func TestResourceDomainCreate_Forwards(t *testing.T) {
+	c := &fakeClient{aliases: map[string]string{}, limits: &improvmx.AccountLimit{Destinations: 2}}
+	config := func(forwards ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"domain": "piedpiper.com",
+			"alias": []interface{}{
+				map[string]interface{}{"alias": "team", "forwards": forwards},
+			},
+		}
+	}
+
+	// the domain is not added when an alias has too many destinations
+	d := schema.TestResourceDataRaw(t, resourceDomain().Schema, config("dinesh@piedpiper.com", "gilfoyle@piedpiper.com", "richard@piedpiper.com"))
+	if diags := resourceDomainCreate(context.Background(), d, c); !diags.HasError() || !strings.Contains(diags[0].Summary, "at most 2") {
+		t.Errorf("wanted an error about the destination limit, got %v", diags)
+	}
+	if c.exists {
+		t.Error("wanted domain not to be added")
+	}
+
+	state := testApplyDomain(t, c, nil, config("gilfoyle@PiedPiper.com", "dinesh@piedpiper.com"))
+	if forward := c.aliases["team"]; forward != "dinesh@piedpiper.com,gilfoyle@piedpiper.com" {
+		t.Errorf("unexpected forward: %q", forward)
+	}
+	for k, v := range state.Attributes {
+		if strings.HasSuffix(k, ".forwards.#") && v != "2" || strings.HasSuffix(k, ".forward") && v != "" {
+			t.Errorf("wanted destinations kept in forwards, got %s = %q", k, v)
+		}
+	}
+}
+
+func TestDiffAliases(t *testing.T) {
+	var old, new []improvmx.Alias
+	for i := 0; i < 500; i++ {
+		a := improvmx.Alias{Alias: fmt.Sprintf("alias%03d", i), Forward: "richard@piedpiper.com"}
+		old = append(old, a)
+		new = append(new, a)
+	}
+	new[42].Forward = "gilfoyle@piedpiper.com"
+	new = append(new[:100], new[101:]...)
+	new = append(new, improvmx.Alias{Alias: "dinesh", Forward: "dinesh@piedpiper.com"})
+
+	deletes, creates, updates := diffAliases(&old, &new)
+	if !cmp.Equal(deletes, []improvmx.Alias{old[100]}) {
+		t.Errorf("unexpected deletes: %v", deletes)
+	}
+	if !cmp.Equal(creates, []improvmx.Alias{{Alias: "dinesh", Forward: "dinesh@piedpiper.com"}}) {
+		t.Errorf("unexpected creates: %v", creates)
+	}
+	if !cmp.Equal(updates, []improvmx.Alias{{Alias: "alias042", Forward: "gilfoyle@piedpiper.com"}}) {
+		t.Errorf("unexpected updates: %v", updates)
+	}
+
+	deletes, creates, updates = diffAliases(&old, nil)
+	if len(deletes) != 500 || creates != nil || updates != nil {
+		t.Errorf("wanted every alias deleted, got %d deletes, %d creates and %d updates", len(deletes), len(creates), len(updates))
+	}
+}
+
+func TestRunParallel(t *testing.T) {
+	var mu sync.Mutex
+	var running, peak, calls int
+	op := func(ctx context.Context) error {
+		mu.Lock()
+		running++
+		calls++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	}
+
+	var ops []func(context.Context) error
+	for i := 0; i < 50; i++ {
+		ops = append(ops, op)
+	}
+	if err := runParallel(context.Background(), ops...); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 50 {
+		t.Errorf("wanted 50 calls, got %d", calls)
+	}
+	if peak > aliasConcurrency {
+		t.Errorf("wanted at most %d calls in flight, got %d", aliasConcurrency, peak)
+	}
+
+	failing := func(ctx context.Context) error { return errors.New("alias rejected") }
+	if err := runParallel(context.Background(), op, failing, op); err == nil {
+		t.Error("expected an error")
+	}
+}
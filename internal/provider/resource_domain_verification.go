@@ -0,0 +1,220 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var verificationRecords = []string{"mx", "spf", "dkim1", "dkim2", "dmarc"}
+
+func resourceDomainVerification() *schema.Resource {
+	return &schema.Resource{
+		Description: "Waits for ImprovMX to confirm a domain's DNS configuration. Creating the resource polls ImprovMX's domain check until the domain, or the selected records, are valid. Use it to order resources after DNS records managed by another provider have propagated.",
+
+		CreateContext: resourceDomainVerificationCreate,
+		ReadContext:   resourceDomainVerificationRead,
+		UpdateContext: resourceDomainVerificationRead,
+		DeleteContext: resourceDomainVerificationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"records": {
+				Description: "Records to wait for. Possible values are `mx`, `spf`, `dkim1`, `dkim2` and `dmarc`. Waits for the whole domain to be valid if not set.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(verificationRecords, false),
+				},
+			},
+			"poll_interval": {
+				Description:  "Number of seconds to wait before checking the domain again. The interval doubles after every check, up to `max_poll_interval`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_poll_interval": {
+				Description:  "Maximum number of seconds to wait between checks.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      120,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"valid": {
+				Description: "True if the domain, or the selected records, were valid when last checked.",
+				Type:        schema.TypeBool,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceDomainVerificationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	check, invalid, err := waitForVerification(
+		ctx,
+		c.Domains(),
+		domain,
+		verificationRecordsFromResourceData(d),
+		time.Duration(d.Get("poll_interval").(int))*time.Second,
+		time.Duration(d.Get("max_poll_interval").(int))*time.Second,
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(invalid) > 0 {
+		return verificationDiagnostics(domain, check, invalid)
+	}
+
+	d.SetId(domain)
+	d.Set("valid", true)
+	return nil
+}
+
+func resourceDomainVerificationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+
+	check, err := c.Domains().Check(ctx, d.Id())
+	if err != nil {
+		return removeIfNotFound(d, err)
+	}
+
+	d.Set("domain", d.Id())
+	d.Set("valid", len(invalidRecords(check, verificationRecordsFromResourceData(d))) == 0)
+	return nil
+}
+
+func resourceDomainVerificationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// verification only exists in state
+	return nil
+}
+
+// waitForVerification polls the domain check until the given records are
+// valid or ctx is done, backing off exponentially between checks. It returns
+// the last check with the records that were still invalid.
+func waitForVerification(
+	ctx context.Context,
+	s improvmx.DomainService,
+	domain string,
+	records []string,
+	interval, maxInterval time.Duration,
+) (*improvmx.Check, []string, error) {
+	var last *improvmx.Check
+	for {
+		check, err := s.Check(improvmx.SkipCache(ctx), domain)
+		if err != nil {
+			// report the last result rather than the cancelled request
+			if ctx.Err() != nil && last != nil {
+				return last, invalidRecords(last, records), nil
+			}
+			return nil, nil, err
+		}
+		last = check
+
+		invalid := invalidRecords(check, records)
+		if len(invalid) == 0 {
+			return check, nil, nil
+		}
+		log.Printf("[DEBUG] waiting for %s records of %s to be valid", strings.Join(invalid, ", "), domain)
+
+		select {
+		case <-ctx.Done():
+			return check, invalid, nil
+		case <-time.After(interval):
+		}
+		if interval *= 2; interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// invalidRecords returns the records that are not yet valid. Without any
+// records selected, the domain's overall validity decides.
+func invalidRecords(check *improvmx.Check, records []string) []string {
+	if len(records) == 0 {
+		if check.Valid {
+			return nil
+		}
+		records = verificationRecords
+	}
+
+	all := checkRecords(check)
+	var invalid []string
+	for _, name := range records {
+		if r := all[name]; r == nil || !r.Valid {
+			invalid = append(invalid, name)
+		}
+	}
+	return invalid
+}
+
+func checkRecords(check *improvmx.Check) map[string]*improvmx.Record {
+	return map[string]*improvmx.Record{
+		"mx":    check.Mx,
+		"spf":   check.Spf,
+		"dkim1": check.Dkim1,
+		"dkim2": check.Dkim2,
+		"dmarc": check.Dmarc,
+	}
+}
+
+func verificationDiagnostics(domain string, check *improvmx.Check, invalid []string) (diags diag.Diagnostics) {
+	all := checkRecords(check)
+	for _, name := range invalid {
+		expected, actual := "(none)", "(none)"
+		if r := all[name]; r != nil {
+			expected = recordValuesString(r.Expected)
+			actual = recordValuesString(r.Values)
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Timed out waiting for %s record of %s to be valid", name, domain),
+			Detail:   fmt.Sprintf("Expected: %s\nActual: %s", expected, actual),
+		})
+	}
+	return diags
+}
+
+func recordValuesString(rv *improvmx.RecordValues) string {
+	if rv == nil || len(*rv) == 0 {
+		return "(none)"
+	}
+	return strings.Join(*rv, ", ")
+}
+
+func verificationRecordsFromResourceData(d *schema.ResourceData) []string {
+	selected := d.Get("records").(*schema.Set)
+
+	// keep records, and so diagnostics, in a stable order
+	var records []string
+	for _, name := range verificationRecords {
+		if selected.Contains(name) {
+			records = append(records, name)
+		}
+	}
+	return records
+}
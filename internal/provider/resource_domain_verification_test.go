@@ -0,0 +1,116 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceDomainVerification(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				// the test domain has no DNS records, so verification times out
+				Config: fmt.Sprintf(`
+					resource "improvmx_domain" "test" {
+						domain = "%s"
+					}
+
+					resource "improvmx_domain_verification" "test" {
+						domain        = improvmx_domain.test.domain
+						records       = ["mx"]
+						poll_interval = 1
+
+						timeouts {
+							create = "5s"
+						}
+					}
+				`, testDomain),
+				ExpectError: regexp.MustCompile(`Timed out waiting for mx record`),
+			},
+		},
+	})
+}
+
+// fakeCheckService returns a sequence of domain checks, repeating the last
+// one once the sequence is exhausted.
+type fakeCheckService struct {
+	improvmx.DomainService
+	checks []*improvmx.Check
+	calls  int
+}
+
+func (s *fakeCheckService) Check(ctx context.Context, domain string) (*improvmx.Check, error) {
+	i := s.calls
+	if i >= len(s.checks) {
+		i = len(s.checks) - 1
+	}
+	s.calls++
+	return s.checks[i], nil
+}
+
+func testCheck(valid bool, validRecords ...string) *improvmx.Check {
+	record := func(name string) *improvmx.Record {
+		for _, r := range validRecords {
+			if r == name {
+				return &improvmx.Record{Valid: true, Expected: &improvmx.RecordValues{name}, Values: &improvmx.RecordValues{name}}
+			}
+		}
+		return &improvmx.Record{Expected: &improvmx.RecordValues{name + ".improvmx.com"}}
+	}
+	return &improvmx.Check{
+		Valid: valid,
+		Mx:    record("mx"),
+		Spf:   record("spf"),
+		Dkim1: record("dkim1"),
+		Dkim2: record("dkim2"),
+		Dmarc: record("dmarc"),
+	}
+}
+
+func TestWaitForVerification_SelectedRecords(t *testing.T) {
+	s := &fakeCheckService{checks: []*improvmx.Check{
+		testCheck(false),
+		testCheck(false, "spf"),
+		testCheck(false, "spf", "mx"),
+	}}
+
+	_, invalid, err := waitForVerification(context.Background(), s, "piedpiper.com", []string{"mx", "spf"}, time.Millisecond, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invalid) != 0 || s.calls != 3 {
+		t.Errorf("unexpected result after %d checks: %v", s.calls, invalid)
+	}
+}
+
+func TestWaitForVerification_Timeout(t *testing.T) {
+	s := &fakeCheckService{checks: []*improvmx.Check{testCheck(false, "mx", "spf")}}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	check, invalid, err := waitForVerification(ctx, s, "piedpiper.com", nil, time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(invalid, []string{"dkim1", "dkim2", "dmarc"}) {
+		t.Errorf("unexpected invalid records: %v", invalid)
+	}
+
+	diags := verificationDiagnostics("piedpiper.com", check, invalid)
+	if len(diags) != 3 {
+		t.Fatalf("unexpected diagnostic count: %d", len(diags))
+	}
+	if !strings.Contains(diags[0].Detail, "Expected: dkim1.improvmx.com") || !strings.Contains(diags[0].Detail, "Actual: (none)") {
+		t.Errorf("unexpected diagnostic detail: %s", diags[0].Detail)
+	}
+}
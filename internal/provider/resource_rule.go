@@ -0,0 +1,270 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceRule() *schema.Resource {
+	return &schema.Resource{
+		Description: "ImprovMX rule resource. Rules route the emails of a domain that match all of their conditions, and are evaluated in order of priority before any aliases.",
+
+		CreateContext: resourceRuleCreate,
+		ReadContext:   resourceRuleRead,
+		UpdateContext: resourceRuleUpdate,
+		DeleteContext: resourceRuleDelete,
+
+		CustomizeDiff: customizeRuleDiff,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRuleImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"priority": {
+				Description:  "Order in which the rule is evaluated. Rules with a lower priority are evaluated first.",
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"active": {
+				Description: "Set to `false` to keep the rule without applying it.",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+			},
+			"condition": {
+				Description: "Conditions an email must match for the rule to apply. All conditions must match.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field": {
+							Description: "Part of the email to match. Possible values are `sender`, `recipient`, `subject` and `header`.",
+							Type:        schema.TypeString,
+							Required:    true,
+							ValidateFunc: validation.StringInSlice([]string{
+								improvmx.RuleFieldSender,
+								improvmx.RuleFieldRecipient,
+								improvmx.RuleFieldSubject,
+								improvmx.RuleFieldHeader,
+							}, false),
+						},
+						"header": {
+							Description: "Name of the header to match. Required when `field` is `header`.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+						"operator": {
+							Description: "How the field is compared to `value`. Possible values are `equals`, `contains`, `starts_with`, `ends_with` and `matches` (regular expression).",
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     improvmx.RuleOperatorContains,
+							ValidateFunc: validation.StringInSlice([]string{
+								improvmx.RuleOperatorEquals,
+								improvmx.RuleOperatorContains,
+								improvmx.RuleOperatorStartsWith,
+								improvmx.RuleOperatorEndsWith,
+								improvmx.RuleOperatorMatches,
+							}, false),
+						},
+						"value": {
+							Description: "Value to compare the field to.",
+							Type:        schema.TypeString,
+							Required:    true,
+						},
+					},
+				},
+			},
+			"action": {
+				Description: "Actions applied to emails matching the rule.",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description: "Action to take. Possible values are `forward`, `drop` and `webhook`.",
+							Type:        schema.TypeString,
+							Required:    true,
+							ValidateFunc: validation.StringInSlice([]string{
+								improvmx.RuleActionForward,
+								improvmx.RuleActionDrop,
+								improvmx.RuleActionWebhook,
+							}, false),
+						},
+						"destination": {
+							Description: "Email address to forward to, or endpoint to POST the email to. Required for `forward` and `webhook` actions.",
+							Type:        schema.TypeString,
+							Optional:    true,
+						},
+					},
+				},
+			},
+			"rule_id": {
+				Description: "Unique ID for rule.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"created": {
+				Description: "Timestamp when the rule was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	rule, err := c.Rules(domain).Create(ctx, ruleFromResourceData(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(joinID(domain, rule.ID))
+
+	return resourceRuleRead(ctx, d, meta)
+}
+
+func resourceRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain, id, err := splitID(d.Id(), "rule_id")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	rule, err := c.Rules(domain).Get(ctx, id)
+	if err != nil {
+		return removeIfNotFound(d, err)
+	}
+
+	d.Set("domain", domain)
+	return resourceDataFromRule(rule, d)
+}
+
+func resourceRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	if _, err := c.Rules(domain).Update(ctx, ruleFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceRuleRead(ctx, d, meta)
+}
+
+func resourceRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	err := ignoreNotFound(c.Rules(domain).Delete(ctx, &improvmx.Rule{ID: d.Get("rule_id").(string)}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	domain, id, err := splitID(d.Id(), "rule_id")
+	if err != nil {
+		return nil, err
+	}
+	d.Set("domain", domain)
+	d.Set("rule_id", id)
+	return []*schema.ResourceData{d}, nil
+}
+
+// customizeRuleDiff checks the attributes a condition or action requires
+// depending on its type, so invalid rules fail at plan time. Values that are
+// not known yet are checked once they are.
+func customizeRuleDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	for i, v := range d.Get("condition").([]interface{}) {
+		item, _ := v.(map[string]interface{})
+		key := fmt.Sprintf("condition.%d.header", i)
+		if item["field"] == improvmx.RuleFieldHeader && item["header"] == "" && d.NewValueKnown(key) {
+			return fmt.Errorf("condition.%d: header must be set when field is %q", i, improvmx.RuleFieldHeader)
+		}
+	}
+
+	for i, v := range d.Get("action").([]interface{}) {
+		item, _ := v.(map[string]interface{})
+		key := fmt.Sprintf("action.%d.destination", i)
+		if typ, ok := item["type"].(string); ok && typ != "" && typ != improvmx.RuleActionDrop && item["destination"] == "" && d.NewValueKnown(key) {
+			return fmt.Errorf("action.%d: destination must be set for %s actions", i, typ)
+		}
+	}
+
+	return nil
+}
+
+func ruleFromResourceData(d *schema.ResourceData) *improvmx.Rule {
+	rule := &improvmx.Rule{
+		ID:     d.Get("rule_id").(string),
+		Rank:   d.Get("priority").(int),
+		Active: d.Get("active").(bool),
+	}
+
+	for _, v := range d.Get("condition").([]interface{}) {
+		item := v.(map[string]interface{})
+		condition := improvmx.RuleCondition{
+			Field:    item["field"].(string),
+			Header:   item["header"].(string),
+			Operator: item["operator"].(string),
+			Value:    item["value"].(string),
+		}
+		rule.Conditions = append(rule.Conditions, condition)
+	}
+
+	for _, v := range d.Get("action").([]interface{}) {
+		item := v.(map[string]interface{})
+		action := improvmx.RuleAction{
+			Type:        item["type"].(string),
+			Destination: item["destination"].(string),
+		}
+		rule.Actions = append(rule.Actions, action)
+	}
+
+	return rule
+}
+
+func resourceDataFromRule(rule *improvmx.Rule, d *schema.ResourceData) diag.Diagnostics {
+	conditions := make([]interface{}, len(rule.Conditions))
+	for i, c := range rule.Conditions {
+		conditions[i] = map[string]interface{}{
+			"field":    c.Field,
+			"header":   c.Header,
+			"operator": c.Operator,
+			"value":    c.Value,
+		}
+	}
+	actions := make([]interface{}, len(rule.Actions))
+	for i, a := range rule.Actions {
+		actions[i] = map[string]interface{}{
+			"type":        a.Type,
+			"destination": a.Destination,
+		}
+	}
+
+	d.Set("rule_id", rule.ID)
+	d.Set("priority", rule.Rank)
+	d.Set("active", rule.Active)
+	d.Set("condition", conditions)
+	d.Set("action", actions)
+	d.Set("created", rule.Created)
+	return nil
+}
@@ -0,0 +1,167 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccRuleConfig(keyword string) string {
+	return fmt.Sprintf(`
+		resource "improvmx_domain" "test" {
+			domain = "%[1]s"
+		}
+
+		resource "improvmx_rule" "test" {
+			domain   = improvmx_domain.test.domain
+			priority = 1
+
+			condition {
+				field = "subject"
+				value = "%[2]s"
+			}
+
+			action {
+				type        = "forward"
+				destination = "support@piedpiper.com"
+			}
+		}
+	`, testDomain, keyword)
+}
+
+func TestAccResourceRule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRuleConfig("help"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("improvmx_rule.test", "rule_id"),
+					resource.TestCheckResourceAttr("improvmx_rule.test", "condition.0.operator", "contains"),
+					resource.TestCheckResourceAttr("improvmx_rule.test", "action.0.destination", "support@piedpiper.com"),
+				),
+			},
+			{
+				Config: testAccRuleConfig("urgent"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("improvmx_rule.test", "condition.0.value", "urgent"),
+				),
+			},
+			{
+				ResourceName:      "improvmx_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccRuleHeaderConfig(header string) string {
+	return fmt.Sprintf(`
+		resource "improvmx_domain" "test" {
+			domain = "%[1]s"
+		}
+
+		resource "improvmx_rule" "test" {
+			domain   = improvmx_domain.test.domain
+			priority = 2
+
+			condition {
+				field    = "header"
+				header   = "%[2]s"
+				operator = "equals"
+				value    = "bulk"
+			}
+
+			action {
+				type        = "webhook"
+				destination = "https://hooks.piedpiper.com/bulk"
+			}
+
+			action {
+				type = "drop"
+			}
+		}
+	`, testDomain, header)
+}
+
+func TestAccResourceRule_Header(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRuleHeaderConfig(""),
+				ExpectError: regexp.MustCompile(`header must be set`),
+				PlanOnly:    true,
+			},
+			{
+				Config: testAccRuleHeaderConfig("Precedence"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("improvmx_rule.test", "condition.0.header", "Precedence"),
+					resource.TestCheckResourceAttr("improvmx_rule.test", "action.0.type", "webhook"),
+					resource.TestCheckResourceAttr("improvmx_rule.test", "action.1.type", "drop"),
+				),
+			},
+			{
+				ResourceName:      "improvmx_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// unknownValue is how NewResourceConfigRaw marks a value that is not known
+// until apply.
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestResourceRule_CustomizeDiff(t *testing.T) {
+	cases := map[string]struct {
+		condition map[string]interface{}
+		action    map[string]interface{}
+		err       string
+	}{
+		"valid": {
+			condition: map[string]interface{}{"field": "header", "header": "Precedence", "value": "bulk"},
+			action:    map[string]interface{}{"type": "drop"},
+		},
+		"missing header": {
+			condition: map[string]interface{}{"field": "header", "value": "bulk"},
+			action:    map[string]interface{}{"type": "drop"},
+			err:       `condition.0: header must be set when field is "header"`,
+		},
+		"unknown header": {
+			condition: map[string]interface{}{"field": "header", "header": unknownValue, "value": "bulk"},
+			action:    map[string]interface{}{"type": "drop"},
+		},
+		"missing destination": {
+			condition: map[string]interface{}{"field": "subject", "value": "help"},
+			action:    map[string]interface{}{"type": "forward"},
+			err:       "action.0: destination must be set for forward actions",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{
+				"domain":    "piedpiper.com",
+				"priority":  1,
+				"condition": []interface{}{tc.condition},
+				"action":    []interface{}{tc.action},
+			})
+			_, err := resourceRule().Diff(context.Background(), nil, config, nil)
+			switch {
+			case tc.err == "" && err != nil:
+				t.Fatalf("unexpected error: %s", err)
+			case tc.err != "" && (err == nil || err.Error() != tc.err):
+				t.Fatalf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
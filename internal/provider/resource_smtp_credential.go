@@ -0,0 +1,198 @@
+package improvmx
+
+import (
+	"context"
+	"log"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSMTPCredential() *schema.Resource {
+	return &schema.Resource{
+		Description: "ImprovMX SMTP credential resource. Credentials are used to send email from a domain through ImprovMX's SMTP servers.",
+
+		CreateContext: resourceSMTPCredentialCreate,
+		ReadContext:   resourceSMTPCredentialRead,
+		UpdateContext: resourceSMTPCredentialUpdate,
+		DeleteContext: resourceSMTPCredentialDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSMTPCredentialImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain name.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"username": {
+				Description: "Username of the credential, used in front of the domain to log in, e.g. “mailer”.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"password": {
+				Description:  "Password of the credential. Changing the password rotates it in place. Exactly one of `password` and `password_wo` must be set.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_wo": {
+				Description:  "Write-only password of the credential, which is never stored in the plan or state. Change `password_version` to rotate it. Requires Terraform 1.11 or later.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				WriteOnly:    true,
+				ExactlyOneOf: []string{"password", "password_wo"},
+			},
+			"password_version": {
+				Description:  "Version of `password_wo`. Changing the version rotates the credential's password to the current value of `password_wo`.",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				RequiredWith: []string{"password_wo"},
+			},
+			"usage": {
+				Description: "Number of emails sent with the credential today.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"created": {
+				Description: "Timestamp when the credential was created.",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceSMTPCredentialCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	input, diags := smtpCredentialFromResourceData(d)
+	if diags.HasError() {
+		return diags
+	}
+
+	credential, err := c.Credentials(domain).Create(ctx, input)
+	if err != nil {
+		// an existing credential is never taken over, as resetting its
+		// password would lock out the mail clients using it
+		if exists, _ := smtpCredentialExists(ctx, c.Credentials(domain), input.Username); exists {
+			return diag.Errorf("SMTP credential %s already exists: import it with `terraform import` to manage it", joinID(domain, input.Username))
+		}
+		return diag.FromErr(err)
+	}
+	d.SetId(joinID(domain, credential.Username))
+
+	return resourceSMTPCredentialRead(ctx, d, meta)
+}
+
+func resourceSMTPCredentialRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain, username, err := splitID(d.Id(), "username")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	credentials, err := c.Credentials(domain).List(ctx)
+	if err != nil {
+		return removeIfNotFound(d, err)
+	}
+
+	for _, cred := range *credentials {
+		if cred.Username != username {
+			continue
+		}
+		d.Set("domain", domain)
+		d.Set("username", cred.Username)
+		d.Set("usage", cred.Usage)
+		d.Set("created", cred.Created)
+		return nil
+	}
+
+	log.Printf("[WARN] SMTP credential %s not found, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceSMTPCredentialUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	if d.HasChanges("password", "password_version") {
+		input, diags := smtpCredentialFromResourceData(d)
+		if diags.HasError() {
+			return diags
+		}
+		if _, err := c.Credentials(domain).Update(ctx, input); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceSMTPCredentialRead(ctx, d, meta)
+}
+
+func resourceSMTPCredentialDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	c := meta.(improvmx.Client)
+	domain := d.Get("domain").(string)
+
+	err := ignoreNotFound(c.Credentials(domain).Delete(ctx, &improvmx.SMTPCredential{
+		Username: d.Get("username").(string),
+	}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// smtpCredentialExists reports whether the domain has a credential with
+// username.
+func smtpCredentialExists(ctx context.Context, s improvmx.CredentialService, username string) (bool, error) {
+	credentials, err := s.List(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, c := range *credentials {
+		if c.Username == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func resourceSMTPCredentialImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	domain, username, err := splitID(d.Id(), "username")
+	if err != nil {
+		return nil, err
+	}
+	d.Set("domain", domain)
+	d.Set("username", username)
+	return []*schema.ResourceData{d}, nil
+}
+
+func smtpCredentialFromResourceData(d *schema.ResourceData) (*improvmx.WriteSMTPCredential, diag.Diagnostics) {
+	credential := &improvmx.WriteSMTPCredential{
+		Username: d.Get("username").(string),
+		Password: d.Get("password").(string),
+	}
+	if credential.Password != "" {
+		return credential, nil
+	}
+
+	// write-only values are only available from the raw config
+	password, diags := d.GetRawConfigAt(cty.GetAttrPath("password_wo"))
+	if diags.HasError() {
+		return nil, diags
+	}
+	if password.Type() == cty.String && password.IsKnown() && !password.IsNull() {
+		credential.Password = password.AsString()
+	}
+	return credential, nil
+}
@@ -0,0 +1,256 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func testAccSMTPCredentialConfig(password string) string {
+	return fmt.Sprintf(`
+		resource "improvmx_domain" "test" {
+			domain = "%[1]s"
+		}
+
+		resource "improvmx_smtp_credential" "test" {
+			domain   = improvmx_domain.test.domain
+			username = "mailer"
+			password = "%[2]s"
+		}
+	`, testDomain, password)
+}
+
+func TestAccResourceSMTPCredential(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSMTPCredentialConfig("correct-horse-battery"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("improvmx_smtp_credential.test", "id", testDomain+"/mailer"),
+					resource.TestMatchResourceAttr("improvmx_smtp_credential.test", "created", regexp.MustCompile(`\d+`)),
+					resource.TestCheckResourceAttr("improvmx_smtp_credential.test", "usage", "0"),
+				),
+			},
+			{
+				// password is rotated in place
+				Config: testAccSMTPCredentialConfig("staple-pied-piper"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("improvmx_smtp_credential.test", "password", "staple-pied-piper"),
+				),
+			},
+			{
+				ResourceName:            "improvmx_smtp_credential.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password"},
+			},
+		},
+		CheckDestroy: func(s *terraform.State) error {
+			for _, rs := range s.RootModule().Resources {
+				if rs.Type != "improvmx_smtp_credential" {
+					continue
+				}
+				domain, username, _ := splitID(rs.Primary.ID, "username")
+				credentials, err := improvmxClient.Credentials(domain).List(context.Background())
+				if err != nil {
+					continue
+				}
+				for _, c := range *credentials {
+					if c.Username == username {
+						return fmt.Errorf("SMTP credential '%s' still available after destroy", rs.Primary.ID)
+					}
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// fakeCredentialService keeps the passwords it is given in memory.
+type fakeCredentialService struct {
+	improvmx.CredentialService
+	passwords map[string]string
+}
+
+func (s *fakeCredentialService) List(ctx context.Context) (*[]improvmx.SMTPCredential, error) {
+	var credentials []improvmx.SMTPCredential
+	for username := range s.passwords {
+		credentials = append(credentials, improvmx.SMTPCredential{Username: username})
+	}
+	return &credentials, nil
+}
+
+// Create rejects existing usernames as a bad request, as ImprovMX does.
+func (s *fakeCredentialService) Create(ctx context.Context, c *improvmx.WriteSMTPCredential) (*improvmx.SMTPCredential, error) {
+	if _, ok := s.passwords[c.Username]; ok {
+		return nil, &improvmx.Error{StatusCode: http.StatusBadRequest}
+	}
+	s.passwords[c.Username] = c.Password
+	return &improvmx.SMTPCredential{Username: c.Username}, nil
+}
+
+func (s *fakeCredentialService) Update(ctx context.Context, c *improvmx.WriteSMTPCredential) (*improvmx.SMTPCredential, error) {
+	s.passwords[c.Username] = c.Password
+	return &improvmx.SMTPCredential{Username: c.Username}, nil
+}
+
+type fakeCredentialClient struct {
+	improvmx.Client
+	credentials *fakeCredentialService
+}
+
+func (c *fakeCredentialClient) Credentials(domain string) improvmx.CredentialService {
+	return c.credentials
+}
+
+func TestResourceSMTPCredential_WriteOnlyPassword(t *testing.T) {
+	ctx := context.Background()
+	credentials := &fakeCredentialService{passwords: map[string]string{}}
+
+	p := New("dev")()
+	p.ConfigureContextFunc = func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return &fakeCredentialClient{credentials: credentials}, nil
+	}
+	server := schema.NewGRPCProviderServer(p)
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	providerType := schemaResp.Provider.ValueType().(tftypes.Object)
+	resourceType := schemaResp.ResourceSchemas["improvmx_smtp_credential"].ValueType().(tftypes.Object)
+
+	providerConfig := testDynamicValue(t, providerType, map[string]tftypes.Value{
+		"api_key": tftypes.NewValue(tftypes.String, "test"),
+	})
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: &providerConfig})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testNoDiagnostics(t, configureResp.Diagnostics)
+
+	// apply plans and applies config against prior, returning the new state
+	apply := func(prior tftypes.Value, password string, version int) tftypes.Value {
+		values := map[string]tftypes.Value{
+			"domain":           tftypes.NewValue(tftypes.String, "piedpiper.com"),
+			"username":         tftypes.NewValue(tftypes.String, "richard"),
+			"password_version": tftypes.NewValue(tftypes.Number, version),
+		}
+		proposed := testDynamicValue(t, resourceType, values)
+		if !prior.IsNull() {
+			// keep computed attributes of the prior state
+			var priorValues map[string]tftypes.Value
+			prior.As(&priorValues)
+			for _, name := range []string{"id", "usage", "created"} {
+				values[name] = priorValues[name]
+			}
+			proposed = testDynamicValue(t, resourceType, values)
+		}
+		values["password_wo"] = tftypes.NewValue(tftypes.String, password)
+		config := testDynamicValue(t, resourceType, values)
+		priorState, _ := tfprotov5.NewDynamicValue(resourceType, prior)
+
+		planResp, err := server.PlanResourceChange(ctx, &tfprotov5.PlanResourceChangeRequest{
+			TypeName:         "improvmx_smtp_credential",
+			PriorState:       &priorState,
+			ProposedNewState: &proposed,
+			Config:           &config,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNoDiagnostics(t, planResp.Diagnostics)
+
+		applyResp, err := server.ApplyResourceChange(ctx, &tfprotov5.ApplyResourceChangeRequest{
+			TypeName:       "improvmx_smtp_credential",
+			PriorState:     &priorState,
+			PlannedState:   planResp.PlannedState,
+			Config:         &config,
+			PlannedPrivate: planResp.PlannedPrivate,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		testNoDiagnostics(t, applyResp.Diagnostics)
+
+		state, err := applyResp.NewState.Unmarshal(resourceType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var stateValues map[string]tftypes.Value
+		state.As(&stateValues)
+		if !stateValues["password_wo"].IsNull() || !stateValues["password"].IsNull() {
+			t.Errorf("password stored in state: %v", state)
+		}
+		return state
+	}
+
+	state := apply(tftypes.NewValue(resourceType, nil), "correct-horse-battery", 1)
+	if credentials.passwords["richard"] != "correct-horse-battery" {
+		t.Errorf("unexpected password after create: %q", credentials.passwords["richard"])
+	}
+
+	// bumping the version rotates the password
+	apply(state, "staple-pied-piper", 2)
+	if credentials.passwords["richard"] != "staple-pied-piper" {
+		t.Errorf("unexpected password after rotation: %q", credentials.passwords["richard"])
+	}
+}
+
+func TestResourceSMTPCredentialCreate_Existing(t *testing.T) {
+	credentials := &fakeCredentialService{passwords: map[string]string{"richard": "correct-horse-battery"}}
+	d := schema.TestResourceDataRaw(t, resourceSMTPCredential().Schema, map[string]interface{}{
+		"domain":   "piedpiper.com",
+		"username": "richard",
+		"password": "staple-pied-piper",
+	})
+
+	diags := resourceSMTPCredentialCreate(context.Background(), d, &fakeCredentialClient{credentials: credentials})
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "terraform import") {
+		t.Errorf("wanted an error suggesting import, got %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("wanted credential not to be added to state, got ID %q", d.Id())
+	}
+	if p := credentials.passwords["richard"]; p != "correct-horse-battery" {
+		t.Errorf("wanted existing password kept, got %q", p)
+	}
+}
+
+// testDynamicValue builds a value of typ from values, setting every other
+// attribute to null.
+func testDynamicValue(t *testing.T, typ tftypes.Object, values map[string]tftypes.Value) tfprotov5.DynamicValue {
+	t.Helper()
+	object := map[string]tftypes.Value{}
+	for name, attrType := range typ.AttributeTypes {
+		object[name] = tftypes.NewValue(attrType, nil)
+		if v, ok := values[name]; ok {
+			object[name] = v
+		}
+	}
+	dv, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, object))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dv
+}
+
+func testNoDiagnostics(t *testing.T, diags []*tfprotov5.Diagnostic) {
+	t.Helper()
+	for _, d := range diags {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+}
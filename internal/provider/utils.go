@@ -1,20 +1,55 @@
 package improvmx
 
 import (
+	"errors"
+	"fmt"
 	"hash/fnv"
+	"log"
+	"strings"
 
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-func hashSetValue(key string) schema.SchemaSetFunc {
-	return func(v interface{}) int {
-		s := v.(map[string]interface{})[key].(string)
-		return hash(s)
-	}
-}
-
 func hash(s string) int {
 	h := fnv.New32a()
 	h.Write([]byte(s))
 	return int(h.Sum32())
 }
+
+// joinID builds the ID of a resource that belongs to a domain, e.g.
+// `piedpiper.com/hello`.
+func joinID(domain, name string) string {
+	return fmt.Sprintf("%s/%s", domain, name)
+}
+
+// splitID parses an ID built by joinID. The name of the second part is only
+// used in the error message.
+func splitID(id, name string) (domain string, value string, err error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("unexpected format of ID (%s), expected domain/%s", id, name)
+	}
+	return parts[0], parts[1], nil
+}
+
+// removeIfNotFound removes the resource from state when err reports that it
+// no longer exists, e.g. after it was deleted from the dashboard, so the next
+// plan re-creates it. Any other error is returned as is.
+func removeIfNotFound(d *schema.ResourceData, err error) diag.Diagnostics {
+	if errors.Is(err, improvmx.ErrNotFound) {
+		log.Printf("[WARN] %s not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	return diag.FromErr(err)
+}
+
+// ignoreNotFound treats deleting an object that no longer exists as success.
+func ignoreNotFound(err error) error {
+	if errors.Is(err, improvmx.ErrNotFound) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+package improvmx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSplitID(t *testing.T) {
+	cases := []struct {
+		id, domain, value string
+		valid             bool
+	}{
+		{"piedpiper.com/billing", "piedpiper.com", "billing", true},
+		{"piedpiper.com/*", "piedpiper.com", "*", true},
+		{"piedpiper.com", "", "", false},
+		{"piedpiper.com/", "", "", false},
+	}
+	for _, tc := range cases {
+		domain, value, err := splitID(tc.id, "alias")
+		if tc.valid != (err == nil) {
+			t.Errorf("splitID(%q): unexpected error: %v", tc.id, err)
+		}
+		if domain != tc.domain || value != tc.value {
+			t.Errorf("splitID(%q): wanted %s/%s, got %s/%s", tc.id, tc.domain, tc.value, domain, value)
+		}
+	}
+}
+
+// TestNotFound checks that objects deleted outside of Terraform are removed
+// from state on read, and that deleting them again succeeds.
+func TestNotFound(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"success":false,"errors":{"domain":["Domain not found"]}}`))
+	}))
+	defer ts.Close()
+	c := improvmx.NewClient(ts.URL, "test", nil)
+
+	cases := map[string]struct {
+		resource *schema.Resource
+		id       string
+		raw      map[string]interface{}
+	}{
+		"domain":              {resourceDomain(), "piedpiper.com", map[string]interface{}{"domain": "piedpiper.com"}},
+		"alias":               {resourceAlias(), "piedpiper.com/richard", map[string]interface{}{"domain": "piedpiper.com", "alias": "richard", "forward": "richard@example.com"}},
+		"catch_all":           {resourceCatchAll(), "piedpiper.com", map[string]interface{}{"domain": "piedpiper.com", "forward": "richard@example.com"}},
+		"domain_aliases":      {resourceDomainAliases(), "piedpiper.com", map[string]interface{}{"domain": "piedpiper.com"}},
+		"domain_verification": {resourceDomainVerification(), "piedpiper.com", map[string]interface{}{"domain": "piedpiper.com"}},
+		"rule":                {resourceRule(), "piedpiper.com/1", map[string]interface{}{"domain": "piedpiper.com"}},
+		"smtp_credential":     {resourceSMTPCredential(), "piedpiper.com/mailer", map[string]interface{}{"domain": "piedpiper.com", "username": "mailer", "password": "correct-horse-battery"}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, tc.resource.Schema, tc.raw)
+			d.SetId(tc.id)
+			if diags := tc.resource.ReadContext(context.Background(), d, c); diags.HasError() {
+				t.Fatalf("read: unexpected error: %v", diags)
+			}
+			if d.Id() != "" {
+				t.Errorf("read: wanted resource removed from state, got ID %q", d.Id())
+			}
+
+			if tc.resource.DeleteContext == nil {
+				return
+			}
+			d.SetId(tc.id)
+			if diags := tc.resource.DeleteContext(context.Background(), d, c); diags.HasError() {
+				t.Errorf("delete: unexpected error: %v", diags)
+			}
+		})
+	}
+}
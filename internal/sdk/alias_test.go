@@ -0,0 +1,96 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAliases_ListAllPages(t *testing.T) {
+	f := newFakeServer(t)
+	c := f.client()
+	ctx := context.Background()
+	if _, err := c.Domains().Add(ctx, &Domain{Domain: "piedpiper.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the default catch-all alias plus 250 more
+	for i := 0; i < 250; i++ {
+		f.aliases["piedpiper.com"] = append(f.aliases["piedpiper.com"], Alias{
+			Alias:   fmt.Sprintf("alias%d", i),
+			Forward: "richard@piedpiper.com",
+		})
+	}
+
+	aliases, err := c.Aliases("piedpiper.com").List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*aliases) != 251 {
+		t.Errorf("unexpected alias count: wanted 251, got %d", len(*aliases))
+	}
+}
+
+func TestAliases_Bulk(t *testing.T) {
+	f := newFakeServer(t)
+	c := f.client()
+	ctx := context.Background()
+	if _, err := c.Domains().Add(ctx, &Domain{Domain: "piedpiper.com"}); err != nil {
+		t.Fatal(err)
+	}
+	s := c.Aliases("piedpiper.com")
+
+	result, err := s.Bulk(ctx, BulkAliasAdd, []Alias{
+		{Alias: "hello", Forward: "richard@piedpiper.com"},
+		{Alias: "*", Forward: "richard@piedpiper.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Added) != 1 || result.Added[0].Alias != "hello" {
+		t.Errorf("unexpected added aliases: %+v", result.Added)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Alias != "*" {
+		t.Errorf("unexpected failed aliases: %+v", result.Failed)
+	}
+
+	result, err = s.Bulk(ctx, BulkAliasDelete, []Alias{{Alias: "hello"}, {Alias: "*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Deleted) != 2 {
+		t.Errorf("unexpected deleted aliases: %+v", result.Deleted)
+	}
+	if len(f.aliases["piedpiper.com"]) != 0 {
+		t.Errorf("aliases remaining after bulk delete: %+v", f.aliases["piedpiper.com"])
+	}
+}
+
+func TestAliases_EscapeCatchAll(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.EscapedPath())
+		fmt.Fprint(w, `{"success": true, "alias": {"alias": "*", "forward": "richard@piedpiper.com"}}`)
+	}))
+	defer ts.Close()
+
+	s := NewClient(ts.URL, "test", nil).Aliases("piedpiper.com")
+	ctx := context.Background()
+	catchAll := &Alias{Alias: CatchAll, Forward: "richard@piedpiper.com"}
+	if _, err := s.Update(ctx, catchAll); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, catchAll); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"PUT /domains/piedpiper.com/aliases/%2A",
+		"DELETE /domains/piedpiper.com/aliases/%2A",
+	}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Errorf("unexpected requests: wanted %v, got %v", expected, paths)
+	}
+}
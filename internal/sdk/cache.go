@@ -0,0 +1,119 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// responseCache holds the raw body of successful GET responses for a limited
+// time. Concurrent requests for the same URL are coalesced into a single API
+// call, and any write to a domain drops the cached responses for that domain.
+type responseCache struct {
+	ttl   time.Duration
+	group singleflight.Group
+
+	mu         sync.Mutex
+	entries    map[string]cacheEntry
+	generation uint64
+}
+
+type skipCacheKey struct{}
+
+// SkipCache returns a context whose requests always reach the API, e.g. when
+// polling for a change. Fresh responses are still used to refresh the cache.
+func SkipCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipCacheKey{}, true)
+}
+
+func skipCache(ctx context.Context) bool {
+	skip, _ := ctx.Value(skipCacheKey{}).(bool)
+	return skip
+}
+
+type cacheEntry struct {
+	data    []byte
+	expires time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: map[string]cacheEntry{},
+	}
+}
+
+// sharedFetchTimeout bounds a fetch shared by coalesced callers, as it runs
+// independently of their contexts.
+const sharedFetchTimeout = 2 * time.Minute
+
+// get returns the cached response for key, calling fetch to populate the
+// cache on a miss or when refresh is set. Callers waiting on the same key
+// share a single fetch, and each stops waiting once its ctx is done.
+func (rc *responseCache) get(ctx context.Context, key string, refresh bool, fetch func() ([]byte, error)) ([]byte, error) {
+	rc.mu.Lock()
+	if e, ok := rc.entries[key]; ok && !refresh && time.Now().Before(e.expires) {
+		rc.mu.Unlock()
+		return e.data, nil
+	}
+	generation := rc.generation
+	rc.mu.Unlock()
+
+	// include the generation in the flight key so a request started before an
+	// invalidation is never shared with a caller that arrives after it
+	flightKey := fmt.Sprintf("%d:%t:%s", generation, refresh, key)
+	ch := rc.group.DoChan(flightKey, func() (interface{}, error) {
+		data, err := fetch()
+		if err != nil {
+			return nil, err
+		}
+		rc.mu.Lock()
+		if rc.generation == generation {
+			rc.entries[key] = cacheEntry{data: data, expires: time.Now().Add(rc.ttl)}
+		}
+		rc.mu.Unlock()
+		return data, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.([]byte), nil
+	}
+}
+
+// invalidate drops every cached response that belongs to the same top-level
+// object as URL, e.g. a write to `/domains/example.com/aliases/hello` drops
+// the cached domain, check and alias responses for `example.com`.
+func (rc *responseCache) invalidate(URL string) {
+	scope := cacheScope(URL)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.generation++
+	for key := range rc.entries {
+		if key == scope || strings.HasPrefix(key, scope+"/") || strings.HasPrefix(key, scope+"?") {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// cacheScope trims URL down to its first two path segments.
+func cacheScope(URL string) string {
+	path := strings.SplitN(URL, "?", 2)[0]
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 2 {
+		segments = segments[:2]
+	}
+	if len(segments) == 2 && segments[1] == "" {
+		segments = segments[:1]
+	}
+	return "/" + strings.Join(segments, "/")
+}
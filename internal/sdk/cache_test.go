@@ -0,0 +1,174 @@
+package improvmx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setupCacheServer(t *testing.T, delay time.Duration) (Client, map[string]*int32) {
+	counts := map[string]*int32{
+		"/domains/example.com":          new(int32),
+		"/domains/example.com/check":    new(int32),
+		"/domains/example.com/aliases/": new(int32),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			if n, ok := counts[r.URL.Path]; ok {
+				atomic.AddInt32(n, 1)
+			}
+		}
+		time.Sleep(delay)
+		w.Header().Set("content-type", "application/json")
+		w.Write([]byte(`{"success": true, "domain": {"domain": "example.com"}, "records": {"valid": true}, "aliases": []}`))
+	}))
+	t.Cleanup(server.Close)
+
+	c := NewClient(server.URL, "test", nil)
+	c.SetCacheTTL(time.Minute)
+	return c, counts
+}
+
+func TestCache_ReusesResponse(t *testing.T) {
+	c, counts := setupCacheServer(t, 0)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		domain, err := c.Domains().Get(ctx, "example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if domain.Domain != "example.com" {
+			t.Errorf("unexpected domain: %s", domain.Domain)
+		}
+		if _, err = c.Domains().Check(ctx, "example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := atomic.LoadInt32(counts["/domains/example.com"]); n != 1 {
+		t.Errorf("unexpected GetDomain request count: wanted 1, got %d", n)
+	}
+	if n := atomic.LoadInt32(counts["/domains/example.com/check"]); n != 1 {
+		t.Errorf("unexpected CheckDomain request count: wanted 1, got %d", n)
+	}
+}
+
+func TestCache_CoalescesConcurrentRequests(t *testing.T) {
+	c, counts := setupCacheServer(t, 50*time.Millisecond)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Domains().Get(ctx, "example.com"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(counts["/domains/example.com"]); n != 1 {
+		t.Errorf("unexpected GetDomain request count: wanted 1, got %d", n)
+	}
+}
+
+func TestCache_CancelledCallerDoesNotFailOthers(t *testing.T) {
+	c, counts := setupCacheServer(t, 100*time.Millisecond)
+
+	// the first caller starts the shared fetch and gives up while it runs
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := c.Domains().Get(ctx, "example.com")
+		first <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	second := make(chan error)
+	go func() {
+		_, err := c.Domains().Get(context.Background(), "example.com")
+		second <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("wanted the cancelled caller to fail with context.Canceled, got %v", err)
+	}
+	if err := <-second; err != nil {
+		t.Errorf("unexpected error for the waiting caller: %v", err)
+	}
+	if n := atomic.LoadInt32(counts["/domains/example.com"]); n != 1 {
+		t.Errorf("unexpected GetDomain request count: wanted 1, got %d", n)
+	}
+}
+
+func TestCache_WriteInvalidatesDomain(t *testing.T) {
+	c, counts := setupCacheServer(t, 0)
+	ctx := context.Background()
+
+	if _, err := c.Domains().Get(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Aliases("example.com").List(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Aliases("example.com").Create(ctx, &Alias{Alias: "hello", Forward: "hello@piedpiper.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Domains().Get(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Aliases("example.com").List(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(counts["/domains/example.com"]); n != 2 {
+		t.Errorf("unexpected GetDomain request count: wanted 2, got %d", n)
+	}
+	if n := atomic.LoadInt32(counts["/domains/example.com/aliases/"]); n != 2 {
+		t.Errorf("unexpected ListAliases request count: wanted 2, got %d", n)
+	}
+}
+
+func TestCache_SkipCache(t *testing.T) {
+	c, counts := setupCacheServer(t, 0)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Domains().Check(SkipCache(ctx), "example.com"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// the last fresh response is reused by later cached reads
+	if _, err := c.Domains().Check(ctx, "example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := atomic.LoadInt32(counts["/domains/example.com/check"]); n != 3 {
+		t.Errorf("unexpected CheckDomain request count: wanted 3, got %d", n)
+	}
+}
+
+func TestCacheScope(t *testing.T) {
+	cases := map[string]string{
+		"/domains/":                        "/domains",
+		"/domains/example.com":             "/domains/example.com",
+		"/domains/example.com/aliases/foo": "/domains/example.com",
+		"/account/":                        "/account",
+		"/account/whitelabels":             "/account/whitelabels",
+	}
+	for URL, expected := range cases {
+		if scope := cacheScope(URL); scope != expected {
+			t.Errorf("cacheScope(%q): wanted %q, got %q", URL, expected, scope)
+		}
+	}
+}
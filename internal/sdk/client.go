@@ -11,10 +11,15 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"strings"
+	"time"
 )
 
 const agent string = "ImprovMX-GoSDK/1.1"
 
+// defaultMaxResponseSize caps the number of bytes read from a single
+// response body.
+const defaultMaxResponseSize int64 = 10 << 20
+
 // NewClient constructs a Checly API client.
 func NewClient(
 	baseURL,
@@ -31,6 +36,8 @@ func NewClient(
 		httpClient: http.DefaultClient,
 		out:        out,
 		userAgent:  &userAgent,
+
+		maxResponseSize: defaultMaxResponseSize,
 	}
 }
 
@@ -46,27 +53,45 @@ func (c *client) SetHTTPClient(client *http.Client) {
 	c.httpClient = client
 }
 
+// SetCacheTTL caches responses from read-only endpoints for the given
+// duration. A duration of zero disables the cache.
+func (c *client) SetCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		c.cache = nil
+		return
+	}
+	c.cache = newResponseCache(ttl)
+}
+
 /* ACCOUNT ⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁ */
 
-func (c *client) GetAccount(ctx context.Context) (*Account, error) {
+type accountService struct {
+	*client
+}
+
+func (c *client) Account() AccountService {
+	return &accountService{c}
+}
+
+func (s *accountService) Get(ctx context.Context) (*Account, error) {
 	var result struct {
 		Account *Account `json:"account,omitempty"`
 		Response
 	}
-	if err := c.apiCall(ctx, http.MethodGet, "/account/", nil, &result); err != nil {
+	if err := s.cachedCall(ctx, "/account/", &result); err != nil {
 		return nil, err
 	}
 	return result.Account, nil
 }
 
-func (c *client) GetWhitelabels(ctx context.Context) (*[]Whitelabel, error) {
+func (s *accountService) Whitelabels(ctx context.Context) (*[]Whitelabel, error) {
 	var result struct {
 		Whitelabels *[]Whitelabel `json:"whitelabels,omitempty"`
 		Response
 	}
 
 	url := "/account/whitelabels"
-	if err := c.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
+	if err := s.cachedCall(ctx, url, &result); err != nil {
 		return nil, err
 	}
 	return result.Whitelabels, nil
@@ -74,80 +99,117 @@ func (c *client) GetWhitelabels(ctx context.Context) (*[]Whitelabel, error) {
 
 /* DOMAIN ⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁ */
 
-func (c *client) ListDomains(ctx context.Context, query *QueryDomain) (*[]Domain, error) {
-	var result struct {
-		Domains *[]Domain `json:"domains,omitempty"`
-		Response
-	}
+type domainService struct {
+	*client
+}
 
-	// todo: properly parse query input and encode url
-	url := "/domains/"
-	if query != nil && query.Query != "" {
-		url = url + "?query=" + query.Query
+func (c *client) Domains() DomainService {
+	return &domainService{c}
+}
+
+// domainPageSize is the number of domains requested per page.
+const domainPageSize = 100
+
+// List returns the domains matching query. Every page is requested unless
+// query selects a single page.
+func (s *domainService) List(ctx context.Context, query *QueryDomain) (*[]Domain, error) {
+	params := url.Values{}
+	params.Set("limit", fmt.Sprint(domainPageSize))
+	if query != nil {
+		if query.Query != "" {
+			params.Set("q", query.Query)
+		}
+		if query.IsActive {
+			params.Set("is_active", "true")
+		}
+		if query.Limit > 0 {
+			params.Set("limit", fmt.Sprint(query.Limit))
+		}
 	}
 
-	if err := c.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
-		return nil, err
+	domains := []Domain{}
+	for page := 1; ; page++ {
+		var result struct {
+			Domains *[]Domain `json:"domains,omitempty"`
+			Response
+		}
+
+		if query != nil && query.Page > 0 {
+			page = query.Page
+		}
+		params.Set("page", fmt.Sprint(page))
+
+		url := "/domains/?" + params.Encode()
+		if err := s.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
+			return nil, err
+		}
+		if result.Domains == nil || len(*result.Domains) == 0 {
+			break
+		}
+		domains = append(domains, *result.Domains...)
+		if (query != nil && query.Page > 0) || len(domains) >= result.Total {
+			break
+		}
 	}
-	return result.Domains, nil
+	return &domains, nil
 }
 
-func (c *client) AddDomain(ctx context.Context, domain *Domain) (*Domain, error) {
+func (s *domainService) Add(ctx context.Context, domain *Domain) (*Domain, error) {
 	var result struct {
 		Domain *Domain `json:"domain,omitempty"`
 		Response
 	}
 
 	url := "/domains/"
-	if err := c.apiCall(ctx, http.MethodPost, url, domain, &result); err != nil {
+	if err := s.apiCall(ctx, http.MethodPost, url, domain, &result); err != nil {
 		return nil, err
 	}
 	return result.Domain, nil
 }
 
-func (c *client) GetDomain(ctx context.Context, domain string) (*Domain, error) {
+func (s *domainService) Get(ctx context.Context, domain string) (*Domain, error) {
 	var result struct {
 		Domain *Domain `json:"domain,omitempty"`
 		Response
 	}
 
 	url := fmt.Sprintf("/domains/%s", domain)
-	if err := c.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
+	if err := s.cachedCall(ctx, url, &result); err != nil {
 		return nil, err
 	}
 	return result.Domain, nil
 }
 
-func (c *client) UpdateDomain(ctx context.Context, domain *Domain) (*Domain, error) {
+func (s *domainService) Update(ctx context.Context, domain *Domain) (*Domain, error) {
 	var result struct {
 		Domain *Domain `json:"domain,omitempty"`
 		Response
 	}
 
 	url := fmt.Sprintf("/domains/%s", domain.Domain)
-	if err := c.apiCall(ctx, http.MethodPut, url, domain, &result); err != nil {
+	if err := s.apiCall(ctx, http.MethodPut, url, domain, &result); err != nil {
 		return nil, err
 	}
 	return result.Domain, nil
 }
 
-func (c *client) DeleteDomain(ctx context.Context, domain *Domain) error {
+func (s *domainService) Delete(ctx context.Context, domain *Domain) error {
 	var result Response
 	url := fmt.Sprintf("/domains/%s", domain.Domain)
-	if err := c.apiCall(ctx, http.MethodDelete, url, nil, &result); err != nil {
+	if err := s.apiCall(ctx, http.MethodDelete, url, nil, &result); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (c *client) CheckDomain(ctx context.Context, domain string) (*Check, error) {
+func (s *domainService) Check(ctx context.Context, domain string) (*Check, error) {
 	var result struct {
 		Records *Check `json:"records,omitempty"`
 		Response
 	}
 
 	url := fmt.Sprintf("/domains/%s/check", domain)
-	if err := c.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
+	if err := s.cachedCall(ctx, url, &result); err != nil {
 		return nil, err
 	}
 	return result.Records, nil
@@ -155,102 +217,246 @@ func (c *client) CheckDomain(ctx context.Context, domain string) (*Check, error)
 
 /* ALIAS ⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁ */
 
-func (c *client) ListAliases(ctx context.Context, domain string) (*[]Alias, error) {
+// aliasPageSize is the number of aliases requested per page.
+const aliasPageSize = 100
+
+type aliasService struct {
+	*client
+	domain string
+}
+
+func (c *client) Aliases(domain string) AliasService {
+	return &aliasService{c, domain}
+}
+
+// List returns every alias of the domain, following pagination until all
+// pages have been read.
+func (s *aliasService) List(ctx context.Context) (*[]Alias, error) {
+	aliases := []Alias{}
+	for page := 1; ; page++ {
+		var result struct {
+			Aliases *[]Alias `json:"aliases,omitempty"`
+			Response
+		}
+
+		url := fmt.Sprintf("/domains/%s/aliases/?limit=%d&page=%d", s.domain, aliasPageSize, page)
+		if err := s.cachedCall(ctx, url, &result); err != nil {
+			return nil, err
+		}
+		if result.Aliases == nil || len(*result.Aliases) == 0 {
+			break
+		}
+		aliases = append(aliases, *result.Aliases...)
+		if len(aliases) >= result.Total {
+			break
+		}
+	}
+	return &aliases, nil
+}
+
+func (s *aliasService) Get(ctx context.Context, alias string) (*Alias, error) {
 	var result struct {
-		Aliases *[]Alias `json:"aliases,omitempty"`
+		Alias *Alias `json:"alias,omitempty"`
 		Response
 	}
 
-	url := fmt.Sprintf("/domains/%s/aliases/", domain)
-	if err := c.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
+	url := fmt.Sprintf("/domains/%s/aliases/%s", s.domain, escapePathSegment(alias))
+	if err := s.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
 		return nil, err
 	}
-	return result.Aliases, nil
+	return result.Alias, nil
 }
 
-func (c *client) CreateAlias(ctx context.Context, domain string, alias *Alias) (*Alias, error) {
+func (s *aliasService) Create(ctx context.Context, alias *Alias) (*Alias, error) {
 	var result struct {
 		Alias *Alias `json:"alias,omitempty"`
 		Response
 	}
 
-	url := fmt.Sprintf("/domains/%s/aliases/", domain)
-	if err := c.apiCall(ctx, http.MethodPost, url, alias, &result); err != nil {
+	url := fmt.Sprintf("/domains/%s/aliases/", s.domain)
+	if err := s.apiCall(ctx, http.MethodPost, url, alias, &result); err != nil {
 		return nil, err
 	}
 	return result.Alias, nil
 }
 
-func (c *client) UpdateAlias(ctx context.Context, domain string, alias *Alias) (*Alias, error) {
+func (s *aliasService) Update(ctx context.Context, alias *Alias) (*Alias, error) {
 	var result struct {
 		Alias *Alias `json:"alias,omitempty"`
 		Response
 	}
 
-	url := fmt.Sprintf("/domains/%s/aliases/%s", domain, alias.Alias)
-	if err := c.apiCall(ctx, http.MethodPut, url, alias, &result); err != nil {
+	url := fmt.Sprintf("/domains/%s/aliases/%s", s.domain, escapePathSegment(alias.Alias))
+	if err := s.apiCall(ctx, http.MethodPut, url, alias, &result); err != nil {
 		return nil, err
 	}
 	return result.Alias, nil
 }
 
-func (c *client) DeleteAlias(ctx context.Context, domain string, alias *Alias) error {
+func (s *aliasService) Delete(ctx context.Context, alias *Alias) error {
 	var result Response
 
-	url := fmt.Sprintf("/domains/%s/aliases/%s", domain, alias.Alias)
-	if err := c.apiCall(ctx, http.MethodDelete, url, nil, &result); err != nil {
+	url := fmt.Sprintf("/domains/%s/aliases/%s", s.domain, escapePathSegment(alias.Alias))
+	if err := s.apiCall(ctx, http.MethodDelete, url, nil, &result); err != nil {
 		return err
 	}
 	return nil
 }
 
+// escapePathSegment escapes a name, such as an alias, credential username or
+// rule ID, for use as a path segment. `*` is valid in a path but is escaped
+// too, so the catch-all alias is never mistaken for a wildcard.
+func escapePathSegment(name string) string {
+	return strings.ReplaceAll(url.PathEscape(name), "*", "%2A")
+}
+
+// Bulk applies behavior to all of the given aliases in a single request.
+func (s *aliasService) Bulk(ctx context.Context, behavior BulkAliasBehavior, aliases []Alias) (*BulkAliasResult, error) {
+	var result struct {
+		BulkAliasResult
+		Response
+	}
+	body := struct {
+		Aliases  []Alias           `json:"aliases"`
+		Behavior BulkAliasBehavior `json:"behavior"`
+	}{aliases, behavior}
+
+	url := fmt.Sprintf("/domains/%s/aliases/bulk", s.domain)
+	if err := s.apiCall(ctx, http.MethodPost, url, body, &result); err != nil {
+		return nil, err
+	}
+	return &result.BulkAliasResult, nil
+}
+
 /* SMTP CREDENTIAL ⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁ */
 
-func (c *client) ListSMTPCredentials(ctx context.Context, domain string) (*[]SMTPCredential, error) {
+type credentialService struct {
+	*client
+	domain string
+}
+
+func (c *client) Credentials(domain string) CredentialService {
+	return &credentialService{c, domain}
+}
+
+func (s *credentialService) List(ctx context.Context) (*[]SMTPCredential, error) {
 	var result struct {
 		Credentials *[]SMTPCredential `json:"credentials,omitempty"`
 		Response
 	}
 
-	url := fmt.Sprintf("/domains/%s/credentials/", domain)
-	if err := c.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
+	url := fmt.Sprintf("/domains/%s/credentials/", s.domain)
+	if err := s.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
 		return nil, err
 	}
 	return result.Credentials, nil
 }
 
-func (c *client) CreateSMTPCredential(ctx context.Context, domain string, credential *WriteSMTPCredential) (*SMTPCredential, error) {
+func (s *credentialService) Create(ctx context.Context, credential *WriteSMTPCredential) (*SMTPCredential, error) {
 	var result struct {
 		Credential         *SMTPCredential `json:"credential,omitempty"`
 		RequiresNewMxCheck bool            `json:"requires_new_mx_check,omitempty"`
 		Response
 	}
 
-	url := fmt.Sprintf("/domains/%s/credentials/", domain)
-	if err := c.apiCall(ctx, http.MethodPost, url, credential, &result); err != nil {
+	url := fmt.Sprintf("/domains/%s/credentials/", s.domain)
+	if err := s.apiCall(ctx, http.MethodPost, url, credential, &result); err != nil {
 		return nil, err
 	}
 	return result.Credential, nil
 }
 
-func (c *client) UpdateSMTPCredential(ctx context.Context, domain string, credential *WriteSMTPCredential) (*SMTPCredential, error) {
+func (s *credentialService) Update(ctx context.Context, credential *WriteSMTPCredential) (*SMTPCredential, error) {
 	var result struct {
 		Credential *SMTPCredential `json:"credential,omitempty"`
 		Response
 	}
 
-	url := fmt.Sprintf("/domains/%s/credentials/%s", domain, credential.Username)
-	if err := c.apiCall(ctx, http.MethodPut, url, credential, &result); err != nil {
+	url := fmt.Sprintf("/domains/%s/credentials/%s", s.domain, escapePathSegment(credential.Username))
+	if err := s.apiCall(ctx, http.MethodPut, url, credential, &result); err != nil {
 		return nil, err
 	}
 	return result.Credential, nil
 }
 
-func (c *client) DeleteSMTPCredential(ctx context.Context, domain string, credential *SMTPCredential) error {
+func (s *credentialService) Delete(ctx context.Context, credential *SMTPCredential) error {
+	var result Response
+
+	url := fmt.Sprintf("/domains/%s/credentials/%s", s.domain, escapePathSegment(credential.Username))
+	if err := s.apiCall(ctx, http.MethodDelete, url, nil, &result); err != nil {
+		return err
+	}
+	return nil
+}
+
+/* RULE ⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁ */
+
+type ruleService struct {
+	*client
+	domain string
+}
+
+func (c *client) Rules(domain string) RuleService {
+	return &ruleService{c, domain}
+}
+
+func (s *ruleService) List(ctx context.Context) (*[]Rule, error) {
+	var result struct {
+		Rules *[]Rule `json:"rules,omitempty"`
+		Response
+	}
+
+	url := fmt.Sprintf("/domains/%s/rules/", s.domain)
+	if err := s.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Rules, nil
+}
+
+func (s *ruleService) Get(ctx context.Context, id string) (*Rule, error) {
+	var result struct {
+		Rule *Rule `json:"rule,omitempty"`
+		Response
+	}
+
+	url := fmt.Sprintf("/domains/%s/rules/%s", s.domain, escapePathSegment(id))
+	if err := s.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Rule, nil
+}
+
+func (s *ruleService) Create(ctx context.Context, rule *Rule) (*Rule, error) {
+	var result struct {
+		Rule *Rule `json:"rule,omitempty"`
+		Response
+	}
+
+	url := fmt.Sprintf("/domains/%s/rules/", s.domain)
+	if err := s.apiCall(ctx, http.MethodPost, url, rule, &result); err != nil {
+		return nil, err
+	}
+	return result.Rule, nil
+}
+
+func (s *ruleService) Update(ctx context.Context, rule *Rule) (*Rule, error) {
+	var result struct {
+		Rule *Rule `json:"rule,omitempty"`
+		Response
+	}
+
+	url := fmt.Sprintf("/domains/%s/rules/%s", s.domain, escapePathSegment(rule.ID))
+	if err := s.apiCall(ctx, http.MethodPut, url, rule, &result); err != nil {
+		return nil, err
+	}
+	return result.Rule, nil
+}
+
+func (s *ruleService) Delete(ctx context.Context, rule *Rule) error {
 	var result Response
 
-	url := fmt.Sprintf("/domains/%s/credentials/%s", domain, credential.Username)
-	if err := c.apiCall(ctx, http.MethodDelete, url, nil, &result); err != nil {
+	url := fmt.Sprintf("/domains/%s/rules/%s", s.domain, escapePathSegment(rule.ID))
+	if err := s.apiCall(ctx, http.MethodDelete, url, nil, &result); err != nil {
 		return err
 	}
 	return nil
@@ -258,7 +464,15 @@ func (c *client) DeleteSMTPCredential(ctx context.Context, domain string, creden
 
 /* DOMAIN / ALIAS LOG ⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁ */
 
-func (c *client) GetLogs(ctx context.Context, query *QueryLog) (*[]Log, error) {
+type logService struct {
+	*client
+}
+
+func (c *client) Logs() LogService {
+	return &logService{c}
+}
+
+func (s *logService) List(ctx context.Context, query *QueryLog) (*[]Log, error) {
 	var result struct {
 		Logs *[]Log `json:"logs,omitempty"`
 		Response
@@ -266,12 +480,12 @@ func (c *client) GetLogs(ctx context.Context, query *QueryLog) (*[]Log, error) {
 
 	var url string
 	if query.Alias != nil {
-		url = fmt.Sprintf("/domains/%s/logs/%s", *query.Domain, *query.Alias)
+		url = fmt.Sprintf("/domains/%s/logs/%s", *query.Domain, escapePathSegment(*query.Alias))
 	} else {
 		url = fmt.Sprintf("/domains/%s/logs", *query.Domain)
 	}
 
-	if err := c.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
+	if err := s.apiCall(ctx, http.MethodGet, url, nil, &result); err != nil {
 		return nil, err
 	}
 	return result.Logs, nil
@@ -279,10 +493,11 @@ func (c *client) GetLogs(ctx context.Context, query *QueryLog) (*[]Log, error) {
 
 /* Misc ⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁⌁ */
 
-func (c *client) handleResponseError(resp *http.Response) error {
+func (c *client) handleResponseError(statusCode int, body io.Reader) error {
 	var res Response
-	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
-		return fmt.Errorf("error decoding response error: %s", err)
+	if err := json.NewDecoder(body).Decode(&res); err != nil {
+		fmt.Fprintf(c.out, "error decoding response error: %s", err)
+		fmt.Fprintln(c.out)
 	}
 	if res.Errors != nil {
 		for k, v := range res.Errors {
@@ -290,7 +505,26 @@ func (c *client) handleResponseError(resp *http.Response) error {
 			fmt.Fprintln(c.out)
 		}
 	}
-	return fmt.Errorf("response error: %s", http.StatusText(resp.StatusCode))
+	return &Error{StatusCode: statusCode, Errors: res.Errors}
+}
+
+// limitedReader reads from r until n bytes have been read, after which it
+// fails with ErrResponseTooLarge rather than silently truncating the body.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		return 0, ErrResponseTooLarge
+	}
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
 }
 
 func (c *client) addQueryParams(params *map[string]string) string {
@@ -301,6 +535,33 @@ func (c *client) addQueryParams(params *map[string]string) string {
 	return q.Encode()
 }
 
+// cachedCall performs a GET request against URL, serving the response from
+// the cache when one has been configured.
+func (c *client) cachedCall(ctx context.Context, URL string, result interface{}) error {
+	if c.cache == nil {
+		return c.apiCall(ctx, http.MethodGet, URL, nil, result)
+	}
+
+	data, err := c.cache.get(ctx, URL, skipCache(ctx), func() ([]byte, error) {
+		// the fetch is shared by every caller waiting on URL, so cancelling
+		// the caller that started it must not fail the others
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sharedFetchTimeout)
+		defer cancel()
+
+		var raw json.RawMessage
+		err := c.apiCall(fetchCtx, http.MethodGet, URL, nil, &raw)
+		return raw, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("error decoding response data: %s", err)
+	}
+	return nil
+}
+
 func (c *client) apiCall(
 	ctx context.Context,
 	method string,
@@ -310,17 +571,30 @@ func (c *client) apiCall(
 ) error {
 	requestURL := c.url + URL
 
-	data, err := json.Marshal(&body)
-	if err != nil {
-		return fmt.Errorf("error generating request payload: %v", err)
+	// writes may change any cached response for the same object
+	if c.cache != nil && method != http.MethodGet {
+		defer c.cache.invalidate(URL)
+	}
+
+	// only send a payload for methods that take one
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error generating request payload: %v", err)
+		}
+		payload = bytes.NewReader(data)
 	}
 
-	req, err := http.NewRequest(method, requestURL, bytes.NewBuffer(data))
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, payload)
 	if err != nil {
 		return fmt.Errorf("failed to create request: %v", err)
 	}
 	req.Header.Add("Authorization", "Basic  api:"+c.apiKey)
-	req.Header.Add("content-type", "application/json")
+	req.Header.Add("Accept", "application/json")
+	if payload != nil {
+		req.Header.Add("content-type", "application/json")
+	}
 	if c.userAgent != nil {
 		req.Header.Add("User-Agent", *c.userAgent)
 	}
@@ -328,30 +602,34 @@ func (c *client) apiCall(
 	// Log request output to stdout
 	requestDump, err := httputil.DumpRequestOut(req, true)
 	if err != nil {
-		return fmt.Errorf("error dumping HTTP request: %v", err)
+		return fmt.Errorf("error dumping HTTP request: %w", err)
 	}
 	fmt.Fprintln(c.out, string(requestDump))
 	fmt.Fprintln(c.out)
 
-	req = req.WithContext(ctx)
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed with: %v", err)
+		return fmt.Errorf("request failed with: %w", err)
 	}
 
 	defer resp.Body.Close()
 
-	// Log response output to stdout
-	responseDump, _ := httputil.DumpResponse(resp, true)
-	fmt.Fprintln(c.out, string(responseDump))
-	fmt.Fprintln(c.out)
+	// Log response headers to stdout, the body is logged as it is decoded
+	responseDump, _ := httputil.DumpResponse(resp, false)
+	fmt.Fprint(c.out, string(responseDump))
+	defer fmt.Fprint(c.out, "\n\n")
+
+	respBody := io.TeeReader(
+		&limitedReader{r: resp.Body, n: c.maxResponseSize},
+		c.out,
+	)
 
 	if resp.StatusCode != http.StatusOK {
-		return c.handleResponseError(resp)
+		return c.handleResponseError(resp.StatusCode, respBody)
 	}
 
-	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("error decoding response data: %s", err)
+	if err = json.NewDecoder(respBody).Decode(&result); err != nil {
+		return fmt.Errorf("error decoding response data: %w", err)
 	}
 
 	return nil
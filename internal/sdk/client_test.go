@@ -218,3 +218,54 @@ func TestIntegration_SMTPCredentials(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+// TestIntegration_Rules checks the wire format of rules against the API, as
+// Rule is not covered by the API documentation.
+func TestIntegration_Rules(t *testing.T) {
+	c := setupClient(t)
+	ctx := context.Background()
+	d := "example.com"
+
+	domain, err := c.Domains().Add(ctx, &Domain{Domain: d})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Domains().Delete(ctx, domain)
+
+	s := c.Rules(d)
+	rule, err := s.Create(ctx, &Rule{
+		Rank:   1,
+		Active: true,
+		Conditions: []RuleCondition{
+			{Field: RuleFieldSubject, Operator: RuleOperatorContains, Value: "invoice"},
+			{Field: RuleFieldHeader, Header: "X-Priority", Operator: RuleOperatorEquals, Value: "1"},
+		},
+		Actions: []RuleAction{
+			{Type: RuleActionForward, Destination: "billing@piedpiper.com"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Delete(ctx, rule)
+
+	created, err := s.Get(ctx, rule.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(created.Conditions, rule.Conditions) || !cmp.Equal(created.Actions, rule.Actions) {
+		t.Errorf("rule read back differs from rule created: %s", cmp.Diff(rule, created))
+	}
+
+	rule.Actions = []RuleAction{{Type: RuleActionDrop}}
+	if _, err = s.Update(ctx, rule); err != nil {
+		t.Fatal(err)
+	}
+	updated, err := s.Get(ctx, rule.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(updated.Actions, rule.Actions) {
+		t.Errorf("unexpected actions after update: %s", cmp.Diff(rule.Actions, updated.Actions))
+	}
+}
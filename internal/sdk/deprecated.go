@@ -0,0 +1,77 @@
+package improvmx
+
+import (
+	"context"
+)
+
+// The methods below predate the service accessors on Client and are kept so
+// existing consumers continue to compile. New endpoints are only added to the
+// services.
+
+func (c *client) GetAccount(ctx context.Context) (*Account, error) {
+	return c.Account().Get(ctx)
+}
+
+func (c *client) GetWhitelabels(ctx context.Context) (*[]Whitelabel, error) {
+	return c.Account().Whitelabels(ctx)
+}
+
+func (c *client) ListDomains(ctx context.Context, query *QueryDomain) (*[]Domain, error) {
+	return c.Domains().List(ctx, query)
+}
+
+func (c *client) AddDomain(ctx context.Context, domain *Domain) (*Domain, error) {
+	return c.Domains().Add(ctx, domain)
+}
+
+func (c *client) GetDomain(ctx context.Context, domain string) (*Domain, error) {
+	return c.Domains().Get(ctx, domain)
+}
+
+func (c *client) UpdateDomain(ctx context.Context, domain *Domain) (*Domain, error) {
+	return c.Domains().Update(ctx, domain)
+}
+
+func (c *client) DeleteDomain(ctx context.Context, domain *Domain) error {
+	return c.Domains().Delete(ctx, domain)
+}
+
+func (c *client) CheckDomain(ctx context.Context, domain string) (*Check, error) {
+	return c.Domains().Check(ctx, domain)
+}
+
+func (c *client) ListAliases(ctx context.Context, domain string) (*[]Alias, error) {
+	return c.Aliases(domain).List(ctx)
+}
+
+func (c *client) CreateAlias(ctx context.Context, domain string, alias *Alias) (*Alias, error) {
+	return c.Aliases(domain).Create(ctx, alias)
+}
+
+func (c *client) UpdateAlias(ctx context.Context, domain string, alias *Alias) (*Alias, error) {
+	return c.Aliases(domain).Update(ctx, alias)
+}
+
+func (c *client) DeleteAlias(ctx context.Context, domain string, alias *Alias) error {
+	return c.Aliases(domain).Delete(ctx, alias)
+}
+
+func (c *client) ListSMTPCredentials(ctx context.Context, domain string) (*[]SMTPCredential, error) {
+	return c.Credentials(domain).List(ctx)
+}
+
+func (c *client) CreateSMTPCredential(ctx context.Context, domain string, credential *WriteSMTPCredential) (*SMTPCredential, error) {
+	return c.Credentials(domain).Create(ctx, credential)
+}
+
+func (c *client) UpdateSMTPCredential(ctx context.Context, domain string, credential *WriteSMTPCredential) (*SMTPCredential, error) {
+	return c.Credentials(domain).Update(ctx, credential)
+}
+
+func (c *client) DeleteSMTPCredential(ctx context.Context, domain string, credential *SMTPCredential) error {
+	return c.Credentials(domain).Delete(ctx, credential)
+}
+
+func (c *client) GetLogs(ctx context.Context, query *QueryLog) (*[]Log, error) {
+	return c.Logs().List(ctx, query)
+}
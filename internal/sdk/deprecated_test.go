@@ -0,0 +1,63 @@
+package improvmx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDeprecated_MatchesServices(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("content-type", "application/json")
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "test", nil)
+	ctx := context.Background()
+	alias := &Alias{Alias: "hello"}
+	credential := &WriteSMTPCredential{Username: "mailer"}
+
+	calls := []struct {
+		name       string
+		deprecated func() error
+		service    func() error
+	}{
+		{
+			"GetDomain",
+			func() error { _, err := c.GetDomain(ctx, "example.com"); return err },
+			func() error { _, err := c.Domains().Get(ctx, "example.com"); return err },
+		},
+		{
+			"CheckDomain",
+			func() error { _, err := c.CheckDomain(ctx, "example.com"); return err },
+			func() error { _, err := c.Domains().Check(ctx, "example.com"); return err },
+		},
+		{
+			"UpdateAlias",
+			func() error { _, err := c.UpdateAlias(ctx, "example.com", alias); return err },
+			func() error { _, err := c.Aliases("example.com").Update(ctx, alias); return err },
+		},
+		{
+			"UpdateSMTPCredential",
+			func() error { _, err := c.UpdateSMTPCredential(ctx, "example.com", credential); return err },
+			func() error { _, err := c.Credentials("example.com").Update(ctx, credential); return err },
+		},
+	}
+
+	for _, call := range calls {
+		requests = nil
+		if err := call.deprecated(); err != nil {
+			t.Fatalf("%s: %s", call.name, err)
+		}
+		if err := call.service(); err != nil {
+			t.Fatalf("%s: %s", call.name, err)
+		}
+		if len(requests) != 2 || requests[0] != requests[1] {
+			t.Errorf("%s: deprecated method and service sent different requests: %v", call.name, requests)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestDomains_ListAllPages(t *testing.T) {
+	f := newFakeServer(t)
+	c := f.client()
+	ctx := context.Background()
+
+	for i := 0; i < 230; i++ {
+		name := fmt.Sprintf("piedpiper%03d.com", i)
+		f.domains[name] = &Domain{Domain: name, Active: i%2 == 0}
+	}
+	f.domains["hooli.com"] = &Domain{Domain: "hooli.com", Active: true}
+
+	domains, err := c.Domains().List(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*domains) != 231 {
+		t.Errorf("unexpected domain count: wanted 231, got %d", len(*domains))
+	}
+
+	domains, err = c.Domains().List(ctx, &QueryDomain{Query: "piedpiper", IsActive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*domains) != 115 {
+		t.Errorf("unexpected filtered domain count: wanted 115, got %d", len(*domains))
+	}
+	for _, d := range *domains {
+		if !d.Active || !strings.HasPrefix(d.Domain, "piedpiper") {
+			t.Errorf("unexpected domain in filtered results: %+v", d)
+		}
+	}
+}
+
+func TestDomains_ListSinglePage(t *testing.T) {
+	f := newFakeServer(t)
+	c := f.client()
+
+	for i := 0; i < 30; i++ {
+		name := fmt.Sprintf("piedpiper%02d.com", i)
+		f.domains[name] = &Domain{Domain: name}
+	}
+
+	query := &QueryDomain{PaginationOptions: PaginationOptions{Limit: 10, Page: 2}}
+	domains, err := c.Domains().List(context.Background(), query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*domains) != 10 || (*domains)[0].Domain != "piedpiper10.com" {
+		t.Errorf("unexpected page of domains: %+v", *domains)
+	}
+	if len(f.requests) != 1 {
+		t.Errorf("expected a single request, got %v", f.requests)
+	}
+}
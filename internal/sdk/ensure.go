@@ -0,0 +1,119 @@
+package improvmx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+)
+
+// EnsureResult reports what an Ensure helper had to do to bring an object in
+// line with the requested one.
+type EnsureResult string
+
+const (
+	EnsureCreated   EnsureResult = "created"
+	EnsureUpdated   EnsureResult = "updated"
+	EnsureUnchanged EnsureResult = "unchanged"
+)
+
+// mayExist reports whether a failed create may have failed because the
+// object already exists. ImprovMX reports duplicates as a bad request, the
+// same status as invalid input, with only a message to tell them apart.
+// Rather than match messages, the Ensure helpers treat a bad request or a
+// conflict as a possible duplicate and look the object up, returning the
+// error of the create if it does not exist.
+func mayExist(err error) bool {
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusConflict || apiErr.StatusCode == http.StatusBadRequest
+}
+
+// EnsureDomain adds domain, or updates the existing domain of the same name
+// if its settings differ.
+func EnsureDomain(ctx context.Context, s DomainService, domain *Domain) (*Domain, EnsureResult, error) {
+	created, addErr := s.Add(ctx, domain)
+	if addErr == nil {
+		return created, EnsureCreated, nil
+	}
+	if !mayExist(addErr) {
+		return nil, "", addErr
+	}
+
+	existing, err := s.Get(ctx, domain.Domain)
+	if errors.Is(err, ErrNotFound) {
+		return nil, "", addErr
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if existing.NotificationEmail == domain.NotificationEmail &&
+		existing.Webhook == domain.Webhook &&
+		existing.Whitelabel == domain.Whitelabel {
+		return existing, EnsureUnchanged, nil
+	}
+
+	updated, err := s.Update(ctx, domain)
+	if err != nil {
+		return nil, "", err
+	}
+	return updated, EnsureUpdated, nil
+}
+
+// EnsureAlias creates alias, or updates the existing alias of the same name
+// if it forwards somewhere else. Forwards are compared by their destinations,
+// so reordered or reformatted destinations are left as they are.
+func EnsureAlias(ctx context.Context, s AliasService, alias *Alias) (*Alias, EnsureResult, error) {
+	created, createErr := s.Create(ctx, alias)
+	if createErr == nil {
+		return created, EnsureCreated, nil
+	}
+	if !mayExist(createErr) {
+		return nil, "", createErr
+	}
+
+	existing, err := s.Get(ctx, alias.Alias)
+	if errors.Is(err, ErrNotFound) {
+		return nil, "", createErr
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if slices.Equal(existing.Destinations(), alias.Destinations()) {
+		return existing, EnsureUnchanged, nil
+	}
+
+	updated, err := s.Update(ctx, alias)
+	if err != nil {
+		return nil, "", err
+	}
+	return updated, EnsureUpdated, nil
+}
+
+// EnsureSMTPCredential creates credential if no credential with the same
+// username exists. The API never returns passwords, so there is no telling
+// whether the password of an existing credential differs; it is reported as
+// unchanged and its password is left as it is, as resetting it would lock
+// out the mail clients using it. Use Update to change the password.
+func EnsureSMTPCredential(ctx context.Context, s CredentialService, credential *WriteSMTPCredential) (*SMTPCredential, EnsureResult, error) {
+	created, createErr := s.Create(ctx, credential)
+	if createErr == nil {
+		return created, EnsureCreated, nil
+	}
+	if !mayExist(createErr) {
+		return nil, "", createErr
+	}
+
+	credentials, err := s.List(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, c := range *credentials {
+		if c.Username == credential.Username {
+			return &c, EnsureUnchanged, nil
+		}
+	}
+	return nil, "", createErr
+}
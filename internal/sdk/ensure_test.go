@@ -0,0 +1,152 @@
+package improvmx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestMayExist(t *testing.T) {
+	f := newFakeServer(t)
+	c := f.client()
+	ctx := context.Background()
+
+	if _, err := c.Domains().Add(ctx, &Domain{Domain: "piedpiper.com"}); err != nil {
+		t.Fatal(err)
+	}
+	// duplicates are reported as a bad request, so they cannot be told apart
+	// from invalid input without looking the object up
+	_, err := c.Domains().Add(ctx, &Domain{Domain: "piedpiper.com"})
+	if !mayExist(err) {
+		t.Errorf("expected duplicate to be looked up, got %v", err)
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.Errors["domain"] == nil {
+		t.Errorf("expected field errors on API error, got %v", err)
+	}
+
+	if !mayExist(&Error{StatusCode: http.StatusConflict}) {
+		t.Errorf("expected conflict to be looked up")
+	}
+	if mayExist(&Error{StatusCode: http.StatusNotFound}) {
+		t.Errorf("expected not found not to be looked up")
+	}
+}
+
+// invalidDomainService rejects every domain as a bad request, as ImprovMX
+// does for invalid input.
+type invalidDomainService struct {
+	DomainService
+}
+
+func (s *invalidDomainService) Add(ctx context.Context, domain *Domain) (*Domain, error) {
+	return nil, &Error{StatusCode: http.StatusBadRequest, Errors: map[string][]string{"domain": {"Invalid domain."}}}
+}
+
+func (s *invalidDomainService) Get(ctx context.Context, domain string) (*Domain, error) {
+	return nil, &Error{StatusCode: http.StatusNotFound}
+}
+
+func TestEnsureDomain_InvalidInput(t *testing.T) {
+	_, _, err := EnsureDomain(context.Background(), &invalidDomainService{}, &Domain{Domain: "piedpiper"})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("expected the bad request of the add, got %v", err)
+	}
+}
+
+func TestEnsureDomain(t *testing.T) {
+	f := newFakeServer(t)
+	s := f.client().Domains()
+	ctx := context.Background()
+
+	steps := []struct {
+		domain   Domain
+		expected EnsureResult
+	}{
+		{Domain{Domain: "piedpiper.com"}, EnsureCreated},
+		{Domain{Domain: "piedpiper.com"}, EnsureUnchanged},
+		{Domain{Domain: "piedpiper.com", Webhook: "https://piedpiper.com/hook"}, EnsureUpdated},
+		{Domain{Domain: "piedpiper.com", Webhook: "https://piedpiper.com/hook"}, EnsureUnchanged},
+	}
+	for i, step := range steps {
+		domain, result, err := EnsureDomain(ctx, s, &step.domain)
+		if err != nil {
+			t.Fatalf("step %d: %s", i, err)
+		}
+		if result != step.expected {
+			t.Errorf("step %d: wanted %s, got %s", i, step.expected, result)
+		}
+		if domain.Webhook != step.domain.Webhook {
+			t.Errorf("step %d: unexpected webhook: %s", i, domain.Webhook)
+		}
+	}
+}
+
+func TestEnsureAlias(t *testing.T) {
+	f := newFakeServer(t)
+	c := f.client()
+	ctx := context.Background()
+	if _, err := c.Domains().Add(ctx, &Domain{Domain: "piedpiper.com"}); err != nil {
+		t.Fatal(err)
+	}
+	s := c.Aliases("piedpiper.com")
+
+	steps := []struct {
+		alias    Alias
+		expected EnsureResult
+	}{
+		{Alias{Alias: "hello", Forward: "richard@piedpiper.com"}, EnsureCreated},
+		{Alias{Alias: "hello", Forward: "richard@piedpiper.com"}, EnsureUnchanged},
+		{Alias{Alias: "hello", Forward: "jared@piedpiper.com"}, EnsureUpdated},
+		{Alias{Alias: "team", Forward: "dinesh@piedpiper.com,gilfoyle@piedpiper.com"}, EnsureCreated},
+		// the same destinations, written differently
+		{Alias{Alias: "team", Forward: "gilfoyle@PiedPiper.com, dinesh@piedpiper.com"}, EnsureUnchanged},
+	}
+	for i, step := range steps {
+		alias, result, err := EnsureAlias(ctx, s, &step.alias)
+		if err != nil {
+			t.Fatalf("step %d: %s", i, err)
+		}
+		if result != step.expected {
+			t.Errorf("step %d: wanted %s, got %s", i, step.expected, result)
+		}
+		if !slices.Equal(alias.Destinations(), step.alias.Destinations()) {
+			t.Errorf("step %d: unexpected forward: %s", i, alias.Forward)
+		}
+	}
+}
+
+func TestEnsureSMTPCredential(t *testing.T) {
+	f := newFakeServer(t)
+	c := f.client()
+	ctx := context.Background()
+	if _, err := c.Domains().Add(ctx, &Domain{Domain: "piedpiper.com"}); err != nil {
+		t.Fatal(err)
+	}
+	s := c.Credentials("piedpiper.com")
+
+	steps := []struct {
+		credential WriteSMTPCredential
+		expected   EnsureResult
+	}{
+		{WriteSMTPCredential{Username: "mailer", Password: "password123"}, EnsureCreated},
+		{WriteSMTPCredential{Username: "mailer", Password: "password456"}, EnsureUnchanged},
+		{WriteSMTPCredential{Username: "mailer"}, EnsureUnchanged},
+	}
+	for i, step := range steps {
+		credential, result, err := EnsureSMTPCredential(ctx, s, &step.credential)
+		if err != nil {
+			t.Fatalf("step %d: %s", i, err)
+		}
+		if result != step.expected {
+			t.Errorf("step %d: wanted %s, got %s", i, step.expected, result)
+		}
+		if credential.Username != "mailer" {
+			t.Errorf("step %d: unexpected username: %s", i, credential.Username)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package improvmx
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ErrNotFound matches errors returned for objects that do not exist, e.g. a
+// domain that has been deleted from the dashboard.
+var ErrNotFound = errors.New("not found")
+
+// ErrResponseTooLarge is returned when a response body exceeds the size
+// limit of the client.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// Error is returned for API responses with an unsuccessful status code.
+type Error struct {
+	StatusCode int
+	Errors     map[string][]string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("response error: %s", http.StatusText(e.StatusCode))
+	if len(e.Errors) == 0 {
+		return msg
+	}
+	return msg + " (" + strings.Join(e.messages(), "; ") + ")"
+}
+
+// Is matches ErrNotFound by status code, e.g.
+// `errors.Is(err, ErrNotFound)`.
+func (e *Error) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// messages flattens the field errors into a stable, sorted list.
+func (e *Error) messages() []string {
+	var msgs []string
+	for k, v := range e.Errors {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", k, strings.Join(v, "; ")))
+	}
+	sort.Strings(msgs)
+	return msgs
+}
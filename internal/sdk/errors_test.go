@@ -0,0 +1,49 @@
+package improvmx
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestError_NotFound(t *testing.T) {
+	f := newFakeServer(t)
+	c := f.client()
+	ctx := context.Background()
+	if _, err := c.Domains().Add(ctx, &Domain{Domain: "piedpiper.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	calls := map[string]func() error{
+		"get missing domain": func() error {
+			_, err := c.Domains().Get(ctx, "hooli.com")
+			return err
+		},
+		"list aliases of missing domain": func() error {
+			_, err := c.Aliases("hooli.com").List(ctx)
+			return err
+		},
+		"list credentials of missing domain": func() error {
+			_, err := c.Credentials("hooli.com").List(ctx)
+			return err
+		},
+		"delete missing alias": func() error {
+			return c.Aliases("piedpiper.com").Delete(ctx, &Alias{Alias: "hello"})
+		},
+		"delete missing rule": func() error {
+			return c.Rules("piedpiper.com").Delete(ctx, &Rule{ID: "42"})
+		},
+	}
+	for name, call := range calls {
+		err := call()
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("%s: expected error to match ErrNotFound, got %v", name, err)
+		}
+	}
+
+	// other errors are not mistaken for missing objects
+	_, err := c.Domains().Add(ctx, &Domain{Domain: "piedpiper.com"})
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a non not-found error, got %v", err)
+	}
+}
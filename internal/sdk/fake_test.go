@@ -0,0 +1,329 @@
+package improvmx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeServer is an in-memory stand-in for the ImprovMX API, covering the
+// domain, alias, SMTP credential and rule endpoints.
+type fakeServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	domains     map[string]*Domain
+	aliases     map[string][]Alias
+	credentials map[string][]SMTPCredential
+	rules       map[string][]Rule
+	requests    []string
+	nextID      int
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	f := &fakeServer{
+		domains:     map[string]*Domain{},
+		aliases:     map[string][]Alias{},
+		credentials: map[string][]SMTPCredential{},
+		rules:       map[string][]Rule{},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeServer) client() Client {
+	return NewClient(f.URL, "test", nil)
+}
+
+func (f *fakeServer) reply(w http.ResponseWriter, status int, body map[string]interface{}) {
+	body["success"] = status == http.StatusOK
+	w.Header().Set("content-type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func (f *fakeServer) fail(w http.ResponseWriter, status int, field, msg string) {
+	f.reply(w, status, map[string]interface{}{
+		"errors": map[string][]string{field: {msg}},
+	})
+}
+
+// page returns the bounds of the page of n items selected by the request's
+// limit and page parameters.
+func (f *fakeServer) page(r *http.Request, n int) (start, end int) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if limit == 0 {
+		limit = 50
+	}
+	if page == 0 {
+		page = 1
+	}
+	start, end = (page-1)*limit, page*limit
+	if start > n {
+		start = n
+	}
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+func (f *fakeServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if segments[0] != "domains" {
+		f.fail(w, http.StatusNotFound, "path", "Not found")
+		return
+	}
+
+	if len(segments) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			q := r.URL.Query()
+			domains := []Domain{}
+			for _, d := range f.domains {
+				if strings.Contains(d.Domain, q.Get("q")) && (q.Get("is_active") == "" || d.Active) {
+					domains = append(domains, *d)
+				}
+			}
+			sort.Slice(domains, func(i, j int) bool { return domains[i].Domain < domains[j].Domain })
+			start, end := f.page(r, len(domains))
+			f.reply(w, http.StatusOK, map[string]interface{}{
+				"domains": domains[start:end],
+				"total":   len(domains),
+			})
+		case http.MethodPost:
+			var d Domain
+			json.NewDecoder(r.Body).Decode(&d)
+			if _, ok := f.domains[d.Domain]; ok {
+				f.fail(w, http.StatusBadRequest, "domain", "This domain is already registered.")
+				return
+			}
+			d.Active = true
+			f.domains[d.Domain] = &d
+			f.nextID++
+			f.aliases[d.Domain] = []Alias{{Alias: "*", Forward: "owner@piedpiper.com", ID: f.nextID}}
+			f.reply(w, http.StatusOK, map[string]interface{}{"domain": d})
+		}
+		return
+	}
+
+	domain, ok := f.domains[segments[1]]
+	if !ok {
+		f.fail(w, http.StatusNotFound, "domain", "Domain not found")
+		return
+	}
+
+	if len(segments) == 2 {
+		switch r.Method {
+		case http.MethodGet:
+			f.reply(w, http.StatusOK, map[string]interface{}{"domain": domain})
+		case http.MethodPut:
+			var d Domain
+			json.NewDecoder(r.Body).Decode(&d)
+			domain.NotificationEmail = d.NotificationEmail
+			domain.Webhook = d.Webhook
+			domain.Whitelabel = d.Whitelabel
+			f.reply(w, http.StatusOK, map[string]interface{}{"domain": domain})
+		case http.MethodDelete:
+			delete(f.domains, domain.Domain)
+			delete(f.aliases, domain.Domain)
+			delete(f.credentials, domain.Domain)
+			f.reply(w, http.StatusOK, map[string]interface{}{})
+		}
+		return
+	}
+
+	switch segments[2] {
+	case "check":
+		f.reply(w, http.StatusOK, map[string]interface{}{"records": Check{Valid: true}})
+	case "aliases":
+		f.serveAliases(w, r, domain.Domain, segments[3:])
+	case "credentials":
+		f.serveCredentials(w, r, domain.Domain, segments[3:])
+	case "rules":
+		f.serveRules(w, r, domain.Domain, segments[3:])
+	default:
+		f.fail(w, http.StatusNotFound, "path", "Not found")
+	}
+}
+
+func (f *fakeServer) serveAliases(w http.ResponseWriter, r *http.Request, domain string, segments []string) {
+	aliases := f.aliases[domain]
+	if len(segments) == 0 || segments[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			start, end := f.page(r, len(aliases))
+			f.reply(w, http.StatusOK, map[string]interface{}{
+				"aliases": aliases[start:end],
+				"total":   len(aliases),
+			})
+		case http.MethodPost:
+			var a Alias
+			json.NewDecoder(r.Body).Decode(&a)
+			for _, existing := range aliases {
+				if existing.Alias == a.Alias {
+					f.fail(w, http.StatusBadRequest, "alias", "This alias already exists.")
+					return
+				}
+			}
+			f.nextID++
+			a.ID = f.nextID
+			f.aliases[domain] = append(aliases, a)
+			f.reply(w, http.StatusOK, map[string]interface{}{"alias": a})
+		}
+		return
+	}
+
+	if segments[0] == "bulk" && r.Method == http.MethodPost {
+		f.serveBulkAliases(w, r, domain)
+		return
+	}
+
+	for i, a := range aliases {
+		if a.Alias != segments[0] {
+			continue
+		}
+		switch r.Method {
+		case http.MethodGet:
+			f.reply(w, http.StatusOK, map[string]interface{}{"alias": a})
+		case http.MethodPut:
+			var update Alias
+			json.NewDecoder(r.Body).Decode(&update)
+			aliases[i].Forward = update.Forward
+			f.reply(w, http.StatusOK, map[string]interface{}{"alias": aliases[i]})
+		case http.MethodDelete:
+			f.aliases[domain] = append(aliases[:i:i], aliases[i+1:]...)
+			f.reply(w, http.StatusOK, map[string]interface{}{})
+		}
+		return
+	}
+	f.fail(w, http.StatusNotFound, "alias", "Alias not found")
+}
+
+func (f *fakeServer) serveBulkAliases(w http.ResponseWriter, r *http.Request, domain string) {
+	var body struct {
+		Aliases  []Alias           `json:"aliases"`
+		Behavior BulkAliasBehavior `json:"behavior"`
+	}
+	json.NewDecoder(r.Body).Decode(&body)
+
+	var result BulkAliasResult
+	for _, a := range body.Aliases {
+		i := -1
+		for j, existing := range f.aliases[domain] {
+			if existing.Alias == a.Alias {
+				i = j
+			}
+		}
+		switch {
+		case body.Behavior == BulkAliasAdd && i == -1:
+			f.nextID++
+			a.ID = f.nextID
+			f.aliases[domain] = append(f.aliases[domain], a)
+			result.Added = append(result.Added, a)
+		case body.Behavior == BulkAliasUpdate && i != -1:
+			f.aliases[domain][i].Forward = a.Forward
+			result.Updated = append(result.Updated, f.aliases[domain][i])
+		case body.Behavior == BulkAliasDelete && i != -1:
+			aliases := f.aliases[domain]
+			f.aliases[domain] = append(aliases[:i:i], aliases[i+1:]...)
+			result.Deleted = append(result.Deleted, a)
+		default:
+			result.Failed = append(result.Failed, a)
+		}
+	}
+	f.reply(w, http.StatusOK, map[string]interface{}{
+		"added":   result.Added,
+		"updated": result.Updated,
+		"deleted": result.Deleted,
+		"failed":  result.Failed,
+	})
+}
+
+func (f *fakeServer) serveCredentials(w http.ResponseWriter, r *http.Request, domain string, segments []string) {
+	credentials := f.credentials[domain]
+	if len(segments) == 0 || segments[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			f.reply(w, http.StatusOK, map[string]interface{}{"credentials": credentials})
+		case http.MethodPost:
+			var c WriteSMTPCredential
+			json.NewDecoder(r.Body).Decode(&c)
+			for _, existing := range credentials {
+				if existing.Username == c.Username {
+					f.fail(w, http.StatusBadRequest, "username", "This username already exists.")
+					return
+				}
+			}
+			created := SMTPCredential{Username: c.Username, Created: 1617272491}
+			f.credentials[domain] = append(credentials, created)
+			f.reply(w, http.StatusOK, map[string]interface{}{"credential": created})
+		}
+		return
+	}
+
+	for i, c := range credentials {
+		if c.Username != segments[0] {
+			continue
+		}
+		switch r.Method {
+		case http.MethodPut:
+			f.reply(w, http.StatusOK, map[string]interface{}{"credential": c})
+		case http.MethodDelete:
+			f.credentials[domain] = append(credentials[:i:i], credentials[i+1:]...)
+			f.reply(w, http.StatusOK, map[string]interface{}{})
+		}
+		return
+	}
+	f.fail(w, http.StatusNotFound, "username", "Credential not found")
+}
+
+func (f *fakeServer) serveRules(w http.ResponseWriter, r *http.Request, domain string, segments []string) {
+	rules := f.rules[domain]
+	if len(segments) == 0 || segments[0] == "" {
+		switch r.Method {
+		case http.MethodGet:
+			f.reply(w, http.StatusOK, map[string]interface{}{"rules": rules})
+		case http.MethodPost:
+			var rule Rule
+			json.NewDecoder(r.Body).Decode(&rule)
+			f.nextID++
+			rule.ID = strconv.Itoa(f.nextID)
+			f.rules[domain] = append(rules, rule)
+			f.reply(w, http.StatusOK, map[string]interface{}{"rule": rule})
+		}
+		return
+	}
+
+	for i, rule := range rules {
+		if rule.ID != segments[0] {
+			continue
+		}
+		switch r.Method {
+		case http.MethodGet:
+			f.reply(w, http.StatusOK, map[string]interface{}{"rule": rule})
+		case http.MethodPut:
+			var update Rule
+			json.NewDecoder(r.Body).Decode(&update)
+			update.ID = rule.ID
+			rules[i] = update
+			f.reply(w, http.StatusOK, map[string]interface{}{"rule": update})
+		case http.MethodDelete:
+			f.rules[domain] = append(rules[:i:i], rules[i+1:]...)
+			f.reply(w, http.StatusOK, map[string]interface{}{})
+		}
+		return
+	}
+	f.fail(w, http.StatusNotFound, "rule", "Rule not found")
+}
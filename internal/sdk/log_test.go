@@ -0,0 +1,35 @@
+package improvmx
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLogs_EscapeAlias(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		fmt.Fprint(w, `{"success": true, "logs": []}`)
+	}))
+	defer ts.Close()
+
+	s := NewClient(ts.URL, "test", nil).Logs()
+	domain := "piedpiper.com"
+	for _, alias := range []string{CatchAll, "richard/hendricks"} {
+		alias := alias
+		if _, err := s.List(context.Background(), &QueryLog{Domain: &domain, Alias: &alias}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []string{
+		"/domains/piedpiper.com/logs/%2A",
+		"/domains/piedpiper.com/logs/richard%2Fhendricks",
+	}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Errorf("unexpected requests: wanted %v, got %v", expected, paths)
+	}
+}
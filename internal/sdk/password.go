@@ -0,0 +1,59 @@
+package improvmx
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+)
+
+// MinPasswordLength is the shortest SMTP password GeneratePassword creates.
+const MinPasswordLength = 12
+
+const (
+	passwordLower  = "abcdefghijklmnopqrstuvwxyz"
+	passwordUpper  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	passwordDigits = "0123456789"
+)
+
+// GeneratePassword returns a random SMTP password of the given length that
+// mixes lower case letters, upper case letters and digits. Symbols are left
+// out as some SMTP clients fail to quote them.
+func GeneratePassword(length int) (string, error) {
+	if length < MinPasswordLength {
+		return "", fmt.Errorf("password length must be at least %d, got %d", MinPasswordLength, length)
+	}
+
+	// one character from each class, then any class for the rest
+	classes := []string{passwordLower, passwordUpper, passwordDigits}
+	all := passwordLower + passwordUpper + passwordDigits
+	password := make([]byte, length)
+	for i := range password {
+		charset := all
+		if i < len(classes) {
+			charset = classes[i]
+		}
+		c, err := randomIndex(len(charset))
+		if err != nil {
+			return "", err
+		}
+		password[i] = charset[c]
+	}
+
+	// shuffle so the required classes are not always at the start
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+	return string(password), nil
+}
+
+func randomIndex(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("error generating password: %w", err)
+	}
+	return int(i.Int64()), nil
+}
@@ -0,0 +1,34 @@
+package improvmx
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeneratePassword(t *testing.T) {
+	seen := map[string]bool{}
+	for i := 0; i < 50; i++ {
+		password, err := GeneratePassword(MinPasswordLength)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(password) != MinPasswordLength {
+			t.Errorf("unexpected password length: %d", len(password))
+		}
+		for _, class := range []string{passwordLower, passwordUpper, passwordDigits} {
+			if !strings.ContainsAny(password, class) {
+				t.Errorf("password %q is missing a character from %q", password, class)
+			}
+		}
+		if seen[password] {
+			t.Errorf("password %q was generated twice", password)
+		}
+		seen[password] = true
+	}
+}
+
+func TestGeneratePassword_TooShort(t *testing.T) {
+	if _, err := GeneratePassword(MinPasswordLength - 1); err == nil {
+		t.Error("expected an error for a password shorter than the minimum length")
+	}
+}
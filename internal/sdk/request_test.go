@@ -0,0 +1,138 @@
+package improvmx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequest_BodyOnlyForWrites(t *testing.T) {
+	type request struct {
+		method      string
+		contentType string
+		body        string
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, request{r.Method, r.Header.Get("content-type"), string(body)})
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "test", nil)
+	ctx := context.Background()
+	if _, err := c.Domains().Get(ctx, "piedpiper.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Domains().Delete(ctx, &Domain{Domain: "piedpiper.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Domains().Add(ctx, &Domain{Domain: "piedpiper.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range requests[:2] {
+		if r.body != "" || r.contentType != "" {
+			t.Errorf("%s request sent unexpected payload: %q (%s)", r.method, r.body, r.contentType)
+		}
+	}
+	post := requests[2]
+	if post.contentType != "application/json" || !strings.Contains(post.body, `"domain":"piedpiper.com"`) {
+		t.Errorf("POST request sent unexpected payload: %q (%s)", post.body, post.contentType)
+	}
+}
+
+func TestRequest_Cancellation(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := NewClient(server.URL, "test", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+	_, err := c.Domains().Get(ctx, "piedpiper.com")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("cancelled request took too long to return: %s", elapsed)
+	}
+}
+
+func TestRequest_CancelledBeforeSend(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "test", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.Domains().Add(ctx, &Domain{Domain: "piedpiper.com"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled error, got %v", err)
+	}
+	if called {
+		t.Error("cancelled request reached the server")
+	}
+}
+
+func TestRequest_ResponseSizeLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"success": true, "domain": {"domain": "` + strings.Repeat("a", 1024) + `"}}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(server.URL, "test", nil)
+	c.(*client).maxResponseSize = 512
+
+	if _, err := c.Domains().Get(context.Background(), "piedpiper.com"); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("expected ErrResponseTooLarge error, got %v", err)
+	}
+}
+
+func TestRequest_EscapesPathSegments(t *testing.T) {
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.EscapedPath())
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer ts.Close()
+
+	c := NewClient(ts.URL, "test", nil)
+	ctx := context.Background()
+	if _, err := c.Credentials("piedpiper.com").Update(ctx, &WriteSMTPCredential{Username: "mailer/*"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Credentials("piedpiper.com").Delete(ctx, &SMTPCredential{Username: "mailer/*"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Rules("piedpiper.com").Delete(ctx, &Rule{ID: "a/b?c"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"PUT /domains/piedpiper.com/credentials/mailer%2F%2A",
+		"DELETE /domains/piedpiper.com/credentials/mailer%2F%2A",
+		"DELETE /domains/piedpiper.com/rules/a%2Fb%3Fc",
+	}
+	if fmt.Sprint(paths) != fmt.Sprint(expected) {
+		t.Errorf("unexpected requests: wanted %v, got %v", expected, paths)
+	}
+}
@@ -0,0 +1,59 @@
+package improvmx
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRules(t *testing.T) {
+	f := newFakeServer(t)
+	c := f.client()
+	ctx := context.Background()
+	if _, err := c.Domains().Add(ctx, &Domain{Domain: "piedpiper.com"}); err != nil {
+		t.Fatal(err)
+	}
+	s := c.Rules("piedpiper.com")
+
+	rule, err := s.Create(ctx, &Rule{
+		Rank:   1,
+		Active: true,
+		Conditions: []RuleCondition{
+			{Field: RuleFieldSubject, Operator: RuleOperatorContains, Value: "invoice"},
+		},
+		Actions: []RuleAction{
+			{Type: RuleActionForward, Destination: "billing@piedpiper.com"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule.ID == "" {
+		t.Fatal("created rule has no ID")
+	}
+
+	rule.Actions = []RuleAction{{Type: RuleActionDrop}}
+	if _, err = s.Update(ctx, rule); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := s.Get(ctx, rule.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cmp.Equal(updated, rule) {
+		t.Errorf("unexpected rule after update: %s", cmp.Diff(rule, updated))
+	}
+
+	if err = s.Delete(ctx, rule); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := s.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules != nil && len(*rules) != 0 {
+		t.Errorf("rules remaining after delete: %+v", *rules)
+	}
+}
@@ -6,42 +6,124 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// Client is the entry point to the ImprovMX API. Endpoints are grouped into
+// services so consumers (and test fakes) only need to depend on the small
+// interface they use.
 type Client interface {
 	SetUserAgent(agent string) error
 	SetHTTPClient(client *http.Client)
+	SetCacheTTL(ttl time.Duration)
 
+	Account() AccountService
+	Domains() DomainService
+	Aliases(domain string) AliasService
+	Credentials(domain string) CredentialService
+	Logs() LogService
+	Rules(domain string) RuleService
+
+	// Deprecated: use Account().Get instead.
 	GetAccount(ctx context.Context) (*Account, error)
+	// Deprecated: use Account().Whitelabels instead.
 	GetWhitelabels(ctx context.Context) (*[]Whitelabel, error)
 
+	// Deprecated: use Domains().List instead.
 	ListDomains(ctx context.Context, query *QueryDomain) (*[]Domain, error)
+	// Deprecated: use Domains().Add instead.
 	AddDomain(ctx context.Context, domain *Domain) (*Domain, error)
+	// Deprecated: use Domains().Get instead.
 	GetDomain(ctx context.Context, domain string) (*Domain, error)
+	// Deprecated: use Domains().Update instead.
 	UpdateDomain(ctx context.Context, domain *Domain) (*Domain, error)
+	// Deprecated: use Domains().Delete instead.
 	DeleteDomain(ctx context.Context, domain *Domain) error
+	// Deprecated: use Domains().Check instead.
 	CheckDomain(ctx context.Context, domain string) (*Check, error)
 
+	// Deprecated: use Aliases(domain).List instead.
 	ListAliases(ctx context.Context, domain string) (*[]Alias, error)
+	// Deprecated: use Aliases(domain).Create instead.
 	CreateAlias(ctx context.Context, domain string, alias *Alias) (*Alias, error)
+	// Deprecated: use Aliases(domain).Update instead.
 	UpdateAlias(ctx context.Context, domain string, alias *Alias) (*Alias, error)
+	// Deprecated: use Aliases(domain).Delete instead.
 	DeleteAlias(ctx context.Context, domain string, alias *Alias) error
 
+	// Deprecated: use Credentials(domain).List instead.
 	ListSMTPCredentials(ctx context.Context, domain string) (*[]SMTPCredential, error)
+	// Deprecated: use Credentials(domain).Create instead.
 	CreateSMTPCredential(ctx context.Context, domain string, credential *WriteSMTPCredential) (*SMTPCredential, error)
+	// Deprecated: use Credentials(domain).Update instead.
 	UpdateSMTPCredential(ctx context.Context, domain string, credential *WriteSMTPCredential) (*SMTPCredential, error)
+	// Deprecated: use Credentials(domain).Delete instead.
 	DeleteSMTPCredential(ctx context.Context, domain string, credential *SMTPCredential) error
 
+	// Deprecated: use Logs().List instead.
 	GetLogs(ctx context.Context, query *QueryLog) (*[]Log, error)
 }
 
+// AccountService reads the details of the account that owns the API key.
+type AccountService interface {
+	Get(ctx context.Context) (*Account, error)
+	Whitelabels(ctx context.Context) (*[]Whitelabel, error)
+}
+
+// DomainService manages the domains registered with the account.
+type DomainService interface {
+	List(ctx context.Context, query *QueryDomain) (*[]Domain, error)
+	Add(ctx context.Context, domain *Domain) (*Domain, error)
+	Get(ctx context.Context, domain string) (*Domain, error)
+	Update(ctx context.Context, domain *Domain) (*Domain, error)
+	Delete(ctx context.Context, domain *Domain) error
+	Check(ctx context.Context, domain string) (*Check, error)
+}
+
+// AliasService manages the aliases of a single domain.
+type AliasService interface {
+	List(ctx context.Context) (*[]Alias, error)
+	Get(ctx context.Context, alias string) (*Alias, error)
+	Create(ctx context.Context, alias *Alias) (*Alias, error)
+	Update(ctx context.Context, alias *Alias) (*Alias, error)
+	Delete(ctx context.Context, alias *Alias) error
+	Bulk(ctx context.Context, behavior BulkAliasBehavior, aliases []Alias) (*BulkAliasResult, error)
+}
+
+// CredentialService manages the SMTP credentials of a single domain.
+type CredentialService interface {
+	List(ctx context.Context) (*[]SMTPCredential, error)
+	Create(ctx context.Context, credential *WriteSMTPCredential) (*SMTPCredential, error)
+	Update(ctx context.Context, credential *WriteSMTPCredential) (*SMTPCredential, error)
+	Delete(ctx context.Context, credential *SMTPCredential) error
+}
+
+// RuleService manages the conditional routing rules of a single domain.
+type RuleService interface {
+	List(ctx context.Context) (*[]Rule, error)
+	Get(ctx context.Context, id string) (*Rule, error)
+	Create(ctx context.Context, rule *Rule) (*Rule, error)
+	Update(ctx context.Context, rule *Rule) (*Rule, error)
+	Delete(ctx context.Context, rule *Rule) error
+}
+
+// LogService reads the email logs of a domain or alias.
+type LogService interface {
+	List(ctx context.Context, query *QueryLog) (*[]Log, error)
+}
+
 type client struct {
 	apiKey     string
 	url        string
 	userAgent  *string
 	httpClient *http.Client
 	out        io.Writer
+	cache      *responseCache
+
+	maxResponseSize int64
 }
 
 type PaginationOptions struct {
@@ -125,12 +207,71 @@ type Domain struct {
 	Aliases           *[]Alias `json:"aliases,omitempty"`
 }
 
+// CatchAll is the alias that receives emails sent to any address of a domain
+// without an alias of its own.
+const CatchAll = "*"
+
 type Alias struct {
 	Alias   string `json:"alias"`
 	Forward string `json:"forward,omitempty"`
 	ID      int    `json:"id,omitempty"`
 }
 
+// Destinations returns the normalized destinations of the alias, which
+// ImprovMX accepts as a comma-separated list in Forward. The destinations are
+// sorted and without duplicates, so equivalent forwards compare equal.
+func (a Alias) Destinations() []string {
+	seen := map[string]bool{}
+	dests := []string{}
+	for _, dest := range strings.Split(a.Forward, ",") {
+		dest = NormalizeDestination(dest)
+		if dest == "" || seen[dest] {
+			continue
+		}
+		seen[dest] = true
+		dests = append(dests, dest)
+	}
+	sort.Strings(dests)
+	return dests
+}
+
+// NormalizeDestination trims spaces from a destination and lowercases the
+// domain of an email address. Endpoints such as webhook URLs are only
+// trimmed, as their paths may be case-sensitive.
+func NormalizeDestination(dest string) string {
+	dest = strings.TrimSpace(dest)
+	if strings.Contains(dest, "://") {
+		return dest
+	}
+	if i := strings.LastIndex(dest, "@"); i >= 0 {
+		return dest[:i] + strings.ToLower(dest[i:])
+	}
+	return dest
+}
+
+// JoinDestinations builds the Forward of an alias from its destinations,
+// normalizing them as Destinations does.
+func JoinDestinations(dests []string) string {
+	return strings.Join(Alias{Forward: strings.Join(dests, ",")}.Destinations(), ",")
+}
+
+// BulkAliasBehavior selects what a bulk alias request does with the aliases
+// it is given.
+type BulkAliasBehavior string
+
+const (
+	BulkAliasAdd    BulkAliasBehavior = "add"
+	BulkAliasUpdate BulkAliasBehavior = "update"
+	BulkAliasDelete BulkAliasBehavior = "delete"
+)
+
+type BulkAliasResult struct {
+	Added   []Alias `json:"added,omitempty"`
+	Updated []Alias `json:"updated,omitempty"`
+	Deleted []Alias `json:"deleted,omitempty"`
+	Failed  []Alias `json:"failed,omitempty"`
+}
+
 type SMTPCredential struct {
 	Username string `json:"username"`
 	Usage    int    `json:"usage,omitempty"`
@@ -142,6 +283,52 @@ type WriteSMTPCredential struct {
 	Password string `json:"password,omitempty"`
 }
 
+// Rule routes the emails of a domain that match all of its conditions.
+// Rules are evaluated in ascending order of rank, before any aliases. Rules
+// are missing from the API documentation, so their wire format is checked
+// against the API by TestIntegration_Rules.
+type Rule struct {
+	ID         string          `json:"id,omitempty"`
+	Rank       int             `json:"rank"`
+	Active     bool            `json:"active"`
+	Conditions []RuleCondition `json:"conditions"`
+	Actions    []RuleAction    `json:"actions"`
+	Created    int64           `json:"created,omitempty"`
+}
+
+// RuleCondition matches a single field of an email. Header is only used
+// when Field is RuleFieldHeader.
+type RuleCondition struct {
+	Field    string `json:"field"`
+	Header   string `json:"header,omitempty"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+}
+
+// RuleAction is applied to emails matching a rule. Destination holds the
+// email address of a forward action or the endpoint of a webhook action.
+type RuleAction struct {
+	Type        string `json:"type"`
+	Destination string `json:"destination,omitempty"`
+}
+
+const (
+	RuleFieldSender    = "sender"
+	RuleFieldRecipient = "recipient"
+	RuleFieldSubject   = "subject"
+	RuleFieldHeader    = "header"
+
+	RuleOperatorEquals     = "equals"
+	RuleOperatorContains   = "contains"
+	RuleOperatorStartsWith = "starts_with"
+	RuleOperatorEndsWith   = "ends_with"
+	RuleOperatorMatches    = "matches"
+
+	RuleActionForward = "forward"
+	RuleActionDrop    = "drop"
+	RuleActionWebhook = "webhook"
+)
+
 type Check struct {
 	Provider string      `json:"provider"`
 	Advanced bool        `json:"advanced"`
@@ -178,34 +365,32 @@ func (values RecordValues) MarshalJSON() ([]byte, error) {
 }
 
 type Log struct {
-	Created    string `json:"created"`
-	CreatedRaw string `json:"created_raw"`
-	Events     []struct {
-		Code    int    `json:"code"`
-		Created string `json:"created"`
-		ID      string `json:"id"`
-		Local   string `json:"local"`
-		Message string `json:"message"`
-		Server  string `json:"server"`
-		Status  string `json:"status"`
-	} `json:"events,omitempty"`
-	Forward struct {
-		Email string `json:"email"`
-		Name  string `json:"name"`
-	} `json:"forward,omitempty"`
-	Hostname  string `json:"hostname"`
-	ID        string `json:"id"`
-	MessageID string `json:"messageId"`
-	Recipient struct {
-		Email string `json:"email"`
-		Name  string `json:"name"`
-	} `json:"recipient,omitempty"`
-	Sender struct {
-		Email string `json:"email"`
-		Name  string `json:"name"`
-	} `json:"sender,omitempty"`
-	Subject   string `json:"subject"`
-	Transport string `json:"transport"`
+	Created    string     `json:"created"`
+	CreatedRaw string     `json:"created_raw"`
+	Events     []LogEvent `json:"events,omitempty"`
+	Forward    LogContact `json:"forward,omitempty"`
+	Hostname   string     `json:"hostname"`
+	ID         string     `json:"id"`
+	MessageID  string     `json:"messageId"`
+	Recipient  LogContact `json:"recipient,omitempty"`
+	Sender     LogContact `json:"sender,omitempty"`
+	Subject    string     `json:"subject"`
+	Transport  string     `json:"transport"`
+}
+
+type LogEvent struct {
+	Code    int    `json:"code"`
+	Created string `json:"created"`
+	ID      string `json:"id"`
+	Local   string `json:"local"`
+	Message string `json:"message"`
+	Server  string `json:"server"`
+	Status  string `json:"status"`
+}
+
+type LogContact struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
 }
 
 type QueryLog struct {
@@ -213,6 +398,28 @@ type QueryLog struct {
 	Alias  *string `json:"alias"`
 }
 
+// Status returns the lowercased status of the log's most recent event, e.g.
+// `delivered` or `hard-bounce`, or an empty string without any events.
+func (l *Log) Status() string {
+	if len(l.Events) == 0 {
+		return ""
+	}
+	return strings.ToLower(l.Events[len(l.Events)-1].Status)
+}
+
+// CreatedTime returns the time the email was received, preferring the raw
+// Unix timestamp over the formatted date.
+func (l *Log) CreatedTime() (time.Time, error) {
+	if sec, err := strconv.ParseInt(l.CreatedRaw, 10, 64); err == nil {
+		return time.Unix(sec, 0).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, l.Created)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("error parsing log creation time: %w", err)
+	}
+	return t.UTC(), nil
+}
+
 func (values *RecordValues) UnmarshalJSON(b []byte) error {
 	// Try array of strings first.
 	var valueArr []string
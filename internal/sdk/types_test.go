@@ -1,6 +1,7 @@
 package improvmx
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -25,3 +26,45 @@ func TestRecordValues_ReturnSlice(t *testing.T) {
 		t.Errorf("RecordValues.UnmarshalJSON() returned invalid value: %s", values)
 	}
 }
+
+func TestLog_Status(t *testing.T) {
+	l := Log{Events: []LogEvent{{Status: "QUEUED"}, {Status: "DELIVERED"}}}
+	if l.Status() != "delivered" {
+		t.Errorf("Log.Status() returned %q", l.Status())
+	}
+	if (&Log{}).Status() != "" {
+		t.Errorf("Log.Status() without events returned %q", (&Log{}).Status())
+	}
+}
+
+func TestLog_CreatedTime(t *testing.T) {
+	for _, l := range []Log{
+		{CreatedRaw: "1617272491"},
+		{Created: "2021-04-01T10:21:31+00:00"},
+	} {
+		created, err := l.CreatedTime()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created.Unix() != 1617272491 {
+			t.Errorf("Log.CreatedTime() returned %s", created)
+		}
+	}
+	if _, err := (&Log{Created: "yesterday"}).CreatedTime(); err == nil {
+		t.Error("expected an error for an invalid creation time")
+	}
+}
+
+func TestAlias_Destinations(t *testing.T) {
+	a := Alias{Forward: " b@Y.com, a@X.COM,,b@y.com ,https://example.com/Hook"}
+	expected := []string{"a@x.com", "b@y.com", "https://example.com/Hook"}
+	if dests := a.Destinations(); !reflect.DeepEqual(dests, expected) {
+		t.Errorf("Alias.Destinations() returned %v, wanted %v", dests, expected)
+	}
+	if dests := (Alias{}).Destinations(); len(dests) != 0 {
+		t.Errorf("Alias.Destinations() without forward returned %v", dests)
+	}
+	if f := JoinDestinations([]string{"b@y.com", "a@x.com"}); f != "a@x.com,b@y.com" {
+		t.Errorf("JoinDestinations() returned %q", f)
+	}
+}
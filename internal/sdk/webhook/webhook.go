@@ -0,0 +1,133 @@
+// Package webhook receives the email events ImprovMX POSTs to the endpoint
+// configured as a domain's webhook.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	improvmx "github.com/christippett/terraform-provider-improvmx/internal/sdk"
+)
+
+// DefaultMaxBodySize is the largest payload, in bytes, a Handler accepts
+// unless configured otherwise.
+const DefaultMaxBodySize int64 = 1 << 20
+
+type EventType string
+
+const (
+	EventQueued     EventType = "queued"
+	EventDelivered  EventType = "delivered"
+	EventRefused    EventType = "refused"
+	EventSoftBounce EventType = "soft-bounce"
+	EventHardBounce EventType = "hard-bounce"
+
+	// EventAll registers a callback for every event type.
+	EventAll EventType = "*"
+)
+
+// Event is a single webhook payload. It shares its shape with the entries
+// returned by the logs endpoint, plus the event type and the domain it was
+// sent for.
+type Event struct {
+	Type   EventType `json:"event"`
+	Domain string    `json:"domain"`
+	improvmx.Log
+}
+
+// Callback handles a decoded event. Returning an error responds to ImprovMX
+// with a server error so the event is retried.
+type Callback func(ctx context.Context, event *Event) error
+
+// Handler is an http.Handler that decodes webhook payloads and dispatches
+// them to the callbacks registered for their event type.
+type Handler struct {
+	MaxBodySize int64
+
+	mu        sync.RWMutex
+	callbacks map[EventType][]Callback
+}
+
+func NewHandler() *Handler {
+	return &Handler{
+		MaxBodySize: DefaultMaxBodySize,
+		callbacks:   map[EventType][]Callback{},
+	}
+}
+
+// On registers callback for events of the given type. Use EventAll to
+// receive every event.
+func (h *Handler) On(eventType EventType, callback Callback) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.callbacks[eventType] = append(h.callbacks[eventType], callback)
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	maxBodySize := h.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		http.Error(w, "error reading request body", http.StatusBadRequest)
+		return
+	}
+	if int64(len(data)) > maxBodySize {
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	event, err := Decode(data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.mu.RLock()
+	callbacks := append(append([]Callback{}, h.callbacks[event.Type]...), h.callbacks[EventAll]...)
+	h.mu.RUnlock()
+
+	for _, callback := range callbacks {
+		if err := callback(r.Context(), event); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// Decode parses a single webhook payload. Payloads without an explicit event
+// type take the status of their most recent log event.
+func Decode(data []byte) (*Event, error) {
+	var event Event
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(&event); err != nil {
+		return nil, fmt.Errorf("error decoding webhook payload: %s", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("error decoding webhook payload: unexpected data after event")
+	}
+
+	if event.Type == "" {
+		event.Type = EventType(event.Status())
+	}
+	event.Type = EventType(strings.ToLower(string(event.Type)))
+	if event.Type == "" {
+		return nil, fmt.Errorf("webhook payload is missing an event type")
+	}
+	return &event, nil
+}
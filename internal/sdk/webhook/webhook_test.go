@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func replay(t *testing.T, h http.Handler, name string) *httptest.ResponseRecorder {
+	payload, err := ioutil.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return post(h, payload)
+}
+
+func post(h http.Handler, payload []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/improvmx", bytes.NewReader(payload))
+	req.Header.Set("content-type", "application/json")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_DispatchesByEventType(t *testing.T) {
+	h := NewHandler()
+	received := map[EventType][]*Event{}
+	for _, eventType := range []EventType{EventDelivered, EventRefused, EventAll} {
+		eventType := eventType
+		h.On(eventType, func(ctx context.Context, event *Event) error {
+			received[eventType] = append(received[eventType], event)
+			return nil
+		})
+	}
+
+	for _, name := range []string{"delivered.json", "refused.json"} {
+		if rec := replay(t, h, name); rec.Code != http.StatusOK {
+			t.Fatalf("%s: unexpected status code: %d", name, rec.Code)
+		}
+	}
+
+	if len(received[EventDelivered]) != 1 || len(received[EventRefused]) != 1 || len(received[EventAll]) != 2 {
+		t.Fatalf("events dispatched to unexpected callbacks: %v", received)
+	}
+
+	delivered := received[EventDelivered][0]
+	if delivered.Domain != "piedpiper.com" || delivered.Sender.Email != "gavin@hooli.com" {
+		t.Errorf("unexpected delivered event: %+v", delivered)
+	}
+	if len(delivered.Events) != 2 || delivered.Events[1].Code != 250 {
+		t.Errorf("unexpected delivered log events: %+v", delivered.Events)
+	}
+
+	// event type is taken from the last log event when not given explicitly
+	refused := received[EventRefused][0]
+	if refused.Type != EventRefused || refused.Subject != "You have won" {
+		t.Errorf("unexpected refused event: %+v", refused)
+	}
+}
+
+func TestHandler_RejectsMalformedPayloads(t *testing.T) {
+	h := NewHandler()
+	h.On(EventAll, func(ctx context.Context, event *Event) error {
+		t.Errorf("callback called for malformed payload: %+v", event)
+		return nil
+	})
+
+	payloads := map[string]string{
+		"invalid json":  `{"event": "delivered",`,
+		"trailing data": `{"event": "delivered"} {"event": "refused"}`,
+		"missing type":  `{"domain": "piedpiper.com"}`,
+	}
+	for name, payload := range payloads {
+		if rec := post(h, []byte(payload)); rec.Code != http.StatusBadRequest {
+			t.Errorf("%s: unexpected status code: wanted %d, got %d", name, http.StatusBadRequest, rec.Code)
+		}
+	}
+}
+
+func TestHandler_RejectsOversizedBody(t *testing.T) {
+	h := NewHandler()
+	h.MaxBodySize = 64
+
+	payload := `{"event": "delivered", "subject": "` + strings.Repeat("a", 64) + `"}`
+	if rec := post(h, []byte(payload)); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("unexpected status code: wanted %d, got %d", http.StatusRequestEntityTooLarge, rec.Code)
+	}
+}
+
+func TestHandler_RejectsOtherMethods(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/improvmx", nil)
+	rec := httptest.NewRecorder()
+	NewHandler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("unexpected status code: wanted %d, got %d", http.StatusMethodNotAllowed, rec.Code)
+	}
+}
+
+func TestHandler_CallbackError(t *testing.T) {
+	h := NewHandler()
+	h.On(EventDelivered, func(ctx context.Context, event *Event) error {
+		return errors.New("unavailable")
+	})
+	if rec := replay(t, h, "delivered.json"); rec.Code != http.StatusInternalServerError {
+		t.Errorf("unexpected status code: wanted %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"log"
 
 	improvmx "github.com/christippett/terraform-provider-improvmx/internal/provider"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -34,15 +34,19 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{ProviderFunc: improvmx.New(version)}
+	// serve the SDKv2 and framework providers as a single provider
+	server, err := improvmx.NewMuxServer(context.Background(), version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 
+	var serveOpts []tf5server.ServeOpt
 	if debugMode {
-		err := plugin.Debug(context.Background(), "registry.terraform.io/christippett/improvmx", opts)
-		if err != nil {
-			log.Fatal(err.Error())
-		}
-		return
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
 	}
 
-	plugin.Serve(opts)
+	err = tf5server.Serve("registry.terraform.io/christippett/improvmx", server, serveOpts...)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 }